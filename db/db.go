@@ -3,6 +3,7 @@ package db
 import (
 	"time"
 
+	"gopkg.in/mgo.v2"
 	uppdb "upper.io/db"
 	"upper.io/db/mongo"
 )
@@ -10,6 +11,8 @@ import (
 var Session uppdb.Database
 
 const (
+	Name = "hybris"
+
 	CacheExpiration      time.Duration = 0
 	CacheCleanupInterval time.Duration = 60 * time.Minute
 )
@@ -17,7 +20,7 @@ const (
 func init() {
 	sess, err := uppdb.Open(mongo.Adapter, mongo.ConnectionURL{
 		Address:  uppdb.Host("127.0.0.1"),
-		Database: "hybris",
+		Database: Name,
 	})
 
 	if err != nil {
@@ -26,3 +29,9 @@ func init() {
 
 	Session = sess
 }
+
+// Driver returns the mgo collection behind a collection, for queries
+// upper.io/db can't express such as aggregations
+func Driver(collection string) *mgo.Collection {
+	return Session.Driver().(*mgo.Session).DB(Name).C(collection)
+}
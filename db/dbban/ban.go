@@ -30,7 +30,7 @@ func init() {
 
 type Ban struct {
 	// Database object id
-	Id bson.ObjectId `json:"id" bson:"id"`
+	Id bson.ObjectId `json:"id" bson:"_id"`
 
 	// User who was banned
 	BanneeId bson.ObjectId `json:"banneeId" bson:"banneeId"`
@@ -0,0 +1,179 @@
+package dbblock
+
+import (
+	"hybris/db"
+	"sync"
+	"time"
+
+	gocache "github.com/pmylund/go-cache"
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+var (
+	collection  uppdb.Collection
+	cache       = gocache.New(db.CacheExpiration, db.CacheCleanupInterval)
+	getMutexes  = map[bson.ObjectId]*sync.Mutex{}
+	lockMutexes = map[bson.ObjectId]*sync.Mutex{}
+)
+
+func init() {
+	coll, err := db.Session.Collection("blocks")
+	if err != nil && err != uppdb.ErrCollectionDoesNotExists {
+		panic(err)
+	}
+	collection = coll
+}
+
+type Block struct {
+	// Database object id
+	Id bson.ObjectId `json:"id" bson:"_id"`
+
+	// User who blocked
+	BlockerId bson.ObjectId `json:"blockerId" bson:"blockerId"`
+
+	// User who got blocked
+	BlockedId bson.ObjectId `json:"blockedId" bson:"blockedId"`
+
+	// When the object was created
+	Created time.Time `json:"created" bson:"created"`
+
+	// When the object was last updated
+	Updated time.Time `json:"updated" bson:"updated"`
+}
+
+func New(blockerId, blockedId bson.ObjectId) (Block, error) {
+	return Block{
+		Id:        bson.NewObjectId(),
+		BlockerId: blockerId,
+		BlockedId: blockedId,
+		Created:   time.Now(),
+		Updated:   time.Now(),
+	}, nil
+}
+
+func Get(query interface{}) (Block, error) {
+	b, err := get(query)
+	if b == nil {
+		return Block{}, err
+	}
+	return *b, err
+}
+
+func get(query interface{}) (*Block, error) {
+	var block *Block
+	if err := collection.Find(query).One(&block); err != nil {
+		return nil, err
+	}
+	return getId(block.Id)
+}
+
+func GetId(id bson.ObjectId) (Block, error) {
+	b, err := getId(id)
+	if b == nil {
+		return Block{}, err
+	}
+	return *b, err
+}
+
+func getId(id bson.ObjectId) (*Block, error) {
+	if _, ok := getMutexes[id]; !ok {
+		getMutexes[id] = &sync.Mutex{}
+	}
+
+	getMutexes[id].Lock()
+	defer getMutexes[id].Unlock()
+
+	if block, found := cache.Get(string(id)); found {
+		return block.(*Block), nil
+	}
+
+	var block *Block
+
+	if err := collection.Find(uppdb.Cond{"_id": id}).One(&block); err != nil {
+		return nil, err
+	}
+
+	cache.Set(string(id), block, gocache.DefaultExpiration)
+
+	return block, nil
+}
+
+func GetMulti(max int, query interface{}) (blocks []Block, err error) {
+	q := collection.Find(query)
+	if max < 0 {
+		err = q.All(&blocks)
+	} else {
+		err = q.Limit(uint(max)).All(&blocks)
+	}
+	return
+}
+
+func Lock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		lockMutexes[id] = &sync.Mutex{}
+	}
+
+	lockMutexes[id].Lock()
+}
+
+func Unlock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		return
+	}
+	lockMutexes[id].Unlock()
+}
+
+func LockGet(id bson.ObjectId) (*Block, error) {
+	Lock(id)
+	return getId(id)
+}
+
+func (b Block) Save() (err error) {
+	b.Updated = time.Now()
+	_, err = collection.Append(b)
+	return
+}
+
+func (b Block) Delete() error {
+	cache.Delete(string(b.Id))
+	return collection.Find(uppdb.Cond{"_id": b.Id}).Remove()
+}
+
+// Extra methods
+
+// Blocked checks whether either user has blocked the other
+func Blocked(a, b bson.ObjectId) (bool, error) {
+	_, err := Get(uppdb.Or{
+		uppdb.Cond{"blockerId": a, "blockedId": b},
+		uppdb.Cond{"blockerId": b, "blockedId": a},
+	})
+	if err == uppdb.ErrNoMoreRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// BlockedWith returns every user that has blocked the user or was blocked by
+// them
+func BlockedWith(userId bson.ObjectId) (map[bson.ObjectId]bool, error) {
+	blocks, err := GetMulti(-1, uppdb.Or{
+		uppdb.Cond{"blockerId": userId},
+		uppdb.Cond{"blockedId": userId},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := map[bson.ObjectId]bool{}
+	for _, b := range blocks {
+		if b.BlockerId == userId {
+			users[b.BlockedId] = true
+		} else {
+			users[b.BlockerId] = true
+		}
+	}
+	return users, nil
+}
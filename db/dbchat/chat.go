@@ -5,6 +5,7 @@ import (
 	"hybris/structs"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	gocache "github.com/pmylund/go-cache"
 	"gopkg.in/mgo.v2/bson"
@@ -19,7 +20,7 @@ var (
 )
 
 func init() {
-	coll, err := db.Session.Collection("media")
+	coll, err := db.Session.Collection("chats")
 	if err != nil && err != uppdb.ErrCollectionDoesNotExists {
 		panic(err)
 	}
@@ -41,9 +42,15 @@ type Chat struct {
 
 	// Chat message
 	// Validation
-	//  Max 300 characters
+	//  Max 300 characters, counted in runes
 	Message string `json:"message" bson:"message"`
 
+	// Users mentioned in the message who were in the community
+	Mentions []bson.ObjectId `json:"mentions" bson:"mentions"`
+
+	// Links found in the message
+	Links []string `json:"links" bson:"links"`
+
 	// Determines whether or not the chat has been deleted
 	Deleted bool `json:"deleted" bson:"deleted"`
 
@@ -57,10 +64,11 @@ type Chat struct {
 	Updated time.Time `json:"updated" bson:"updated"`
 }
 
+// Most runes a chat message can have
+const MaxLength = 300
+
 func New(userId, communityId bson.ObjectId, me bool, message string) (Chat, error) {
-	if len(message) > 300 {
-		message = message[:300]
-	}
+	message = Truncate(message)
 
 	return Chat{
 		Id:          bson.NewObjectId(),
@@ -68,6 +76,8 @@ func New(userId, communityId bson.ObjectId, me bool, message string) (Chat, erro
 		CommunityId: communityId,
 		Me:          me,
 		Message:     message,
+		Mentions:    []bson.ObjectId{},
+		Links:       []string{},
 		Deleted:     false,
 		DeleterId:   "",
 		Created:     time.Now(),
@@ -165,11 +175,13 @@ func (c Chat) Delete() error {
 
 func (c Chat) Struct() structs.Chat {
 	return structs.Chat{
-		Id:      c.Id,
-		UserId:  c.UserId,
-		Me:      c.Me,
-		Message: c.Message,
-		Time:    c.Created,
+		Id:       c.Id,
+		UserId:   c.UserId,
+		Me:       c.Me,
+		Message:  c.Message,
+		Mentions: c.Mentions,
+		Links:    c.Links,
+		Time:     c.Created,
 	}
 }
 
@@ -179,3 +191,61 @@ func StructMulti(chats []Chat) (payload []structs.Chat) {
 	}
 	return
 }
+
+// Extra methods
+
+// Truncate cuts a message down to MaxLength runes
+func Truncate(message string) string {
+	if utf8.RuneCountInString(message) > MaxLength {
+		return string([]rune(message)[:MaxLength])
+	}
+	return message
+}
+
+// GetRecent finds chats from the newest one backwards. Chat ids grow in the
+// order chats were sent, so older chats are fetched by asking for ids below
+// the oldest one already loaded.
+func GetRecent(max int, query interface{}) (chats []Chat, err error) {
+	q := collection.Find(query).Sort("-_id")
+	if max < 0 {
+		err = q.All(&chats)
+	} else {
+		err = q.Limit(uint(max)).All(&chats)
+	}
+	return
+}
+
+// DeleteMulti marks every chat matching the query as deleted by the deleter in
+// a single update and drops them from the cache. Returns the ids of the chats
+// that were deleted.
+func DeleteMulti(query interface{}, deleterId bson.ObjectId) ([]bson.ObjectId, error) {
+	var rows []struct {
+		Id bson.ObjectId `bson:"_id"`
+	}
+	if err := collection.Find(query).Select("_id").All(&rows); err != nil {
+		return nil, err
+	}
+
+	ids := make([]bson.ObjectId, len(rows))
+	for i, row := range rows {
+		ids[i] = row.Id
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	// Only the selected chats, so chats sent in between aren't missed by the
+	// caller
+	if err := collection.Find(uppdb.Cond{"_id": bson.M{"$in": ids}}).Update(bson.M{
+		"deleted":   true,
+		"deleterId": deleterId,
+		"updated":   time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		cache.Delete(string(id))
+	}
+	return ids, nil
+}
@@ -14,6 +14,9 @@ import (
 	uppdb "upper.io/db"
 )
 
+// Most communities a user can host
+const MaxHosted = 3
+
 var (
 	collection  uppdb.Collection
 	cache       = gocache.New(db.CacheExpiration, db.CacheCleanupInterval)
@@ -57,6 +60,22 @@ type Community struct {
 	// Whether or not the community is marked as NSFW
 	Nsfw bool `json:"nsfw" bson:"nsfw"`
 
+	// Chat messages a user can send in a burst before being rate limited
+	// 0 uses the default
+	ChatLimit int `json:"chatLimit" bson:"chatLimit"`
+
+	// Seconds it takes for the chat limit to refill completely
+	// 0 uses the default
+	ChatLimitPeriod int `json:"chatLimitPeriod" bson:"chatLimitPeriod"`
+
+	// Words and patterns filtered from chat
+	// See enums/FilterActions
+	ChatFilter []structs.ChatFilterRule `json:"chatFilter" bson:"chatFilter"`
+
+	// Whether or not links are blocked for users below bouncer. Communities
+	// stored before this existed allow links.
+	LinksDisabled bool `json:"linksDisabled" bson:"linksDisabled"`
+
 	// When the object was created
 	Created time.Time `json:"created" bson:"created"`
 
@@ -87,6 +106,9 @@ func New(host bson.ObjectId, url, name string, nsfw bool) (Community, error) {
 		WaitlistEnabled: true,
 		DjRecycling:     true,
 		Nsfw:            nsfw,
+		ChatLimit:       5,
+		ChatLimitPeriod: 10,
+		ChatFilter:      []structs.ChatFilterRule{},
 		Created:         time.Now(),
 		Updated:         time.Now(),
 	}, nil
@@ -196,6 +218,17 @@ func (c Community) Struct() structs.CommunityInfo {
 	}
 }
 
+// Settings includes the chat settings, which are only shown to managers
+func (c Community) Settings() structs.CommunitySettings {
+	return structs.CommunitySettings{
+		CommunityInfo:   c.Struct(),
+		ChatLimit:       c.ChatLimit,
+		ChatLimitPeriod: c.ChatLimitPeriod,
+		ChatFilter:      c.ChatFilter,
+		LinksDisabled:   c.LinksDisabled,
+	}
+}
+
 func StructMulti(communities []Community) (payload []structs.CommunityInfo) {
 	for _, c := range communities {
 		payload = append(payload, c.Struct())
@@ -3,6 +3,7 @@ package dbcommunityhistory
 import (
 	"hybris/db"
 	"hybris/db/dbmedia"
+	"hybris/db/dbuser"
 	"hybris/structs"
 	"sync"
 	"time"
@@ -46,6 +47,9 @@ type CommunityHistory struct {
 	// Artist of the media inherited from PlaylistItem
 	Artist string `json:"artist" bson:"artist"`
 
+	// Moderator who skipped the media or removed the DJ, if any
+	ModeratorId bson.ObjectId `json:"moderatorId,omitempty" bson:"moderatorId,omitempty"`
+
 	// Amount of times people wooted
 	Woots int `json:"woots" bson:"woots"`
 
@@ -169,8 +173,15 @@ func (ch CommunityHistory) Struct() structs.HistoryItem {
 	if err != nil {
 		return structs.HistoryItem{}
 	}
+	dj, err := dbuser.GetId(ch.UserId)
+	if err != nil {
+		return structs.HistoryItem{}
+	}
 	return structs.HistoryItem{
-		Dj: ch.UserId,
+		Id:          ch.Id,
+		Time:        ch.Created,
+		Dj:          dj.Struct(),
+		ModeratorId: ch.ModeratorId,
 		Media: structs.ResolvedMediaInfo{
 			media.Struct(),
 			ch.Artist,
@@ -190,3 +201,18 @@ func StructMulti(communityHistory []CommunityHistory) (payload []structs.History
 	}
 	return
 }
+
+// Extra methods
+
+// GetRecent finds entries of the community history from the latest play
+// backwards. The id of the last entry returned is the cursor for the next
+// page.
+func GetRecent(max int, query interface{}) (communityHistory []CommunityHistory, err error) {
+	q := collection.Find(query).Sort("-_id")
+	if max < 0 {
+		err = q.All(&communityHistory)
+	} else {
+		err = q.Limit(uint(max)).All(&communityHistory)
+	}
+	return
+}
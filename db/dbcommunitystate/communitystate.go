@@ -0,0 +1,88 @@
+package dbcommunitystate
+
+import (
+	"hybris/db"
+	"hybris/structs"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+var collection uppdb.Collection
+
+func init() {
+	coll, err := db.Session.Collection("communityState")
+	if err != nil && err != uppdb.ErrCollectionDoesNotExists {
+		panic(err)
+	}
+	collection = coll
+}
+
+// CommunityState is a snapshot of a realtime community. It isn't cached since
+// the realtime community is the source of truth while the server is running.
+type CommunityState struct {
+	// Database object id
+	// Same as the id of the community this belongs to
+	Id bson.ObjectId `json:"id" bson:"_id"`
+
+	// Users waiting to DJ in order
+	Waitlist []bson.ObjectId `json:"waitlist" bson:"waitlist"`
+
+	// Media currently playing, if any
+	Media *structs.CommunityPlayingInfo `json:"media" bson:"media"`
+
+	// Sequence number of the last event sent to the community
+	Seq int `json:"seq" bson:"seq"`
+
+	// When the object was last updated
+	Updated time.Time `json:"updated" bson:"updated"`
+}
+
+func New(communityId bson.ObjectId, waitlist []bson.ObjectId, media *structs.CommunityPlayingInfo, seq int) (CommunityState, error) {
+	return CommunityState{
+		Id:       communityId,
+		Waitlist: waitlist,
+		Media:    media,
+		Seq:      seq,
+		Updated:  time.Now(),
+	}, nil
+}
+
+func GetId(id bson.ObjectId) (CommunityState, error) {
+	var cs CommunityState
+	err := collection.Find(uppdb.Cond{"_id": id}).One(&cs)
+	return cs, err
+}
+
+func GetMulti(max int, query interface{}) (communityStates []CommunityState, err error) {
+	q := collection.Find(query)
+	if max < 0 {
+		err = q.All(&communityStates)
+	} else {
+		err = q.Limit(uint(max)).All(&communityStates)
+	}
+	return
+}
+
+// Save replaces the existing snapshot of the community, if any
+func (cs CommunityState) Save() (err error) {
+	cs.Updated = time.Now()
+	res := collection.Find(uppdb.Cond{"_id": cs.Id})
+
+	count, err := res.Count()
+	if err != nil {
+		return
+	}
+
+	if count > 0 {
+		return res.Update(cs)
+	}
+
+	_, err = collection.Append(cs)
+	return
+}
+
+func (cs CommunityState) Delete() error {
+	return collection.Find(uppdb.Cond{"_id": cs.Id}).Remove()
+}
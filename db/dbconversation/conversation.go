@@ -0,0 +1,173 @@
+package dbconversation
+
+import (
+	"crypto/sha1"
+	"hybris/db"
+	"sync"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+var (
+	collection  uppdb.Collection
+	lockMutexes = map[bson.ObjectId]*sync.Mutex{}
+)
+
+func init() {
+	coll, err := db.Session.Collection("conversations")
+	if err != nil && err != uppdb.ErrCollectionDoesNotExists {
+		panic(err)
+	}
+	collection = coll
+}
+
+// Conversation is a direct message conversation as seen by one of its two
+// users. Each user has their own so their conversations can be listed without
+// going through every message. It isn't cached since it's only read when
+// listing conversations.
+type Conversation struct {
+	// Database object id
+	// Derived from the owner and the other user, see IdOf
+	Id bson.ObjectId `json:"id" bson:"_id"`
+
+	// User this side of the conversation belongs to
+	OwnerId bson.ObjectId `json:"ownerId" bson:"ownerId"`
+
+	// User the owner is talking to
+	OtherId bson.ObjectId `json:"otherId" bson:"otherId"`
+
+	// Most recent message sent by either user
+	LastMessageId bson.ObjectId `json:"lastMessageId" bson:"lastMessageId"`
+
+	// Messages sent to the owner they haven't read yet
+	Unread int `json:"unread" bson:"unread"`
+
+	// When the object was last updated
+	Updated time.Time `json:"updated" bson:"updated"`
+}
+
+func New(ownerId, otherId bson.ObjectId) (Conversation, error) {
+	return Conversation{
+		Id:      IdOf(ownerId, otherId),
+		OwnerId: ownerId,
+		OtherId: otherId,
+		Updated: time.Now(),
+	}, nil
+}
+
+func GetId(id bson.ObjectId) (Conversation, error) {
+	var c Conversation
+	err := collection.Find(uppdb.Cond{"_id": id}).One(&c)
+	return c, err
+}
+
+func GetMulti(max int, query interface{}) (conversations []Conversation, err error) {
+	q := collection.Find(query)
+	if max < 0 {
+		err = q.All(&conversations)
+	} else {
+		err = q.Limit(uint(max)).All(&conversations)
+	}
+	return
+}
+
+func Lock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		lockMutexes[id] = &sync.Mutex{}
+	}
+
+	lockMutexes[id].Lock()
+}
+
+func Unlock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		return
+	}
+	lockMutexes[id].Unlock()
+}
+
+// Save replaces the stored conversation, if any
+func (c Conversation) Save() (err error) {
+	c.Updated = time.Now()
+	res := collection.Find(uppdb.Cond{"_id": c.Id})
+
+	count, err := res.Count()
+	if err != nil {
+		return
+	}
+
+	if count > 0 {
+		return res.Update(c)
+	}
+
+	_, err = collection.Append(c)
+	return
+}
+
+func (c Conversation) Delete() error {
+	return collection.Find(uppdb.Cond{"_id": c.Id}).Remove()
+}
+
+// Extra methods
+
+// IdOf returns the id of the side of the conversation between two users that
+// belongs to the owner. The id is derived from the users so both sides can be
+// updated without looking them up first.
+func IdOf(ownerId, otherId bson.ObjectId) bson.ObjectId {
+	sum := sha1.Sum([]byte(string(ownerId) + string(otherId)))
+	return bson.ObjectId(sum[:12])
+}
+
+// GetLatest finds conversations ordered by their last message, newest first.
+// The last message id of the final conversation returned pages further.
+func GetLatest(max int, query interface{}) (conversations []Conversation, err error) {
+	q := collection.Find(query).Sort("-lastMessageId")
+	if max < 0 {
+		err = q.All(&conversations)
+	} else {
+		err = q.Limit(uint(max)).All(&conversations)
+	}
+	return
+}
+
+// Record moves a message to the top of the side of the conversation that
+// belongs to the owner. Unread is set if the message was sent to the owner.
+func Record(ownerId, otherId, messageId bson.ObjectId, unread bool) error {
+	id := IdOf(ownerId, otherId)
+	Lock(id)
+	defer Unlock(id)
+
+	c, err := GetId(id)
+	if err == uppdb.ErrNoMoreRows {
+		c, err = New(ownerId, otherId)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.LastMessageId = messageId
+	if unread {
+		c.Unread++
+	}
+	return c.Save()
+}
+
+// MarkRead clears the unread count of the side of the conversation that
+// belongs to the owner
+func MarkRead(ownerId, otherId bson.ObjectId) error {
+	id := IdOf(ownerId, otherId)
+	Lock(id)
+	defer Unlock(id)
+
+	c, err := GetId(id)
+	if err == uppdb.ErrNoMoreRows {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	c.Unread = 0
+	return c.Save()
+}
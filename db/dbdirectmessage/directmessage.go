@@ -0,0 +1,232 @@
+package dbdirectmessage
+
+import (
+	"errors"
+	"hybris/db"
+	"hybris/structs"
+	"hybris/validation"
+	"sync"
+	"time"
+
+	gocache "github.com/pmylund/go-cache"
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+var (
+	collection  uppdb.Collection
+	cache       = gocache.New(db.CacheExpiration, db.CacheCleanupInterval)
+	getMutexes  = map[bson.ObjectId]*sync.Mutex{}
+	lockMutexes = map[bson.ObjectId]*sync.Mutex{}
+)
+
+func init() {
+	coll, err := db.Session.Collection("directMessages")
+	if err != nil && err != uppdb.ErrCollectionDoesNotExists {
+		panic(err)
+	}
+	collection = coll
+}
+
+type DirectMessage struct {
+	// Database object id
+	Id bson.ObjectId `json:"id" bson:"_id"`
+
+	// User who sent the message
+	SenderId bson.ObjectId `json:"senderId" bson:"senderId"`
+
+	// User who received the message
+	RecipientId bson.ObjectId `json:"recipientId" bson:"recipientId"`
+
+	// Message
+	// Validation
+	//  1-500 characters
+	Message string `json:"message" bson:"message"`
+
+	// Whether or not the recipient has read the message
+	Read bool `json:"read" bson:"read"`
+
+	// When the object was created
+	Created time.Time `json:"created" bson:"created"`
+
+	// When the object was last updated
+	Updated time.Time `json:"updated" bson:"updated"`
+}
+
+func New(senderId, recipientId bson.ObjectId, message string) (DirectMessage, error) {
+	if !validation.DirectMessage(message) {
+		return DirectMessage{}, errors.New("Invalid message.")
+	}
+
+	return DirectMessage{
+		Id:          bson.NewObjectId(),
+		SenderId:    senderId,
+		RecipientId: recipientId,
+		Message:     message,
+		Read:        false,
+		Created:     time.Now(),
+		Updated:     time.Now(),
+	}, nil
+}
+
+func Get(query interface{}) (DirectMessage, error) {
+	dm, err := get(query)
+	if dm == nil {
+		return DirectMessage{}, err
+	}
+	return *dm, err
+}
+
+func get(query interface{}) (*DirectMessage, error) {
+	var directMessage *DirectMessage
+	if err := collection.Find(query).One(&directMessage); err != nil {
+		return nil, err
+	}
+	return getId(directMessage.Id)
+}
+
+func GetId(id bson.ObjectId) (DirectMessage, error) {
+	dm, err := getId(id)
+	if dm == nil {
+		return DirectMessage{}, err
+	}
+	return *dm, err
+}
+
+func getId(id bson.ObjectId) (*DirectMessage, error) {
+	if _, ok := getMutexes[id]; !ok {
+		getMutexes[id] = &sync.Mutex{}
+	}
+
+	getMutexes[id].Lock()
+	defer getMutexes[id].Unlock()
+
+	if directMessage, found := cache.Get(string(id)); found {
+		return directMessage.(*DirectMessage), nil
+	}
+
+	var directMessage *DirectMessage
+
+	if err := collection.Find(uppdb.Cond{"_id": id}).One(&directMessage); err != nil {
+		return nil, err
+	}
+
+	cache.Set(string(id), directMessage, gocache.DefaultExpiration)
+
+	return directMessage, nil
+}
+
+func GetMulti(max int, query interface{}) (directMessages []DirectMessage, err error) {
+	q := collection.Find(query)
+	if max < 0 {
+		err = q.All(&directMessages)
+	} else {
+		err = q.Limit(uint(max)).All(&directMessages)
+	}
+	return
+}
+
+func Lock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		lockMutexes[id] = &sync.Mutex{}
+	}
+
+	lockMutexes[id].Lock()
+}
+
+func Unlock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		return
+	}
+	lockMutexes[id].Unlock()
+}
+
+func LockGet(id bson.ObjectId) (*DirectMessage, error) {
+	Lock(id)
+	return getId(id)
+}
+
+func (dm DirectMessage) Save() (err error) {
+	dm.Updated = time.Now()
+	_, err = collection.Append(dm)
+	return
+}
+
+func (dm DirectMessage) Delete() error {
+	cache.Delete(string(dm.Id))
+	return collection.Find(uppdb.Cond{"_id": dm.Id}).Remove()
+}
+
+func (dm DirectMessage) Struct() structs.DirectMessage {
+	return structs.DirectMessage{
+		Id:          dm.Id,
+		SenderId:    dm.SenderId,
+		RecipientId: dm.RecipientId,
+		Message:     dm.Message,
+		Read:        dm.Read,
+		Time:        dm.Created,
+	}
+}
+
+func StructMulti(directMessages []DirectMessage) (payload []structs.DirectMessage) {
+	for _, dm := range directMessages {
+		payload = append(payload, dm.Struct())
+	}
+	return
+}
+
+// Extra methods
+
+// GetRecent finds direct messages starting with the one sent last. Scrolling
+// back through a conversation passes the id of its oldest loaded message as an
+// upper bound.
+func GetRecent(max int, query interface{}) (directMessages []DirectMessage, err error) {
+	q := collection.Find(query).Sort("-_id")
+	if max < 0 {
+		err = q.All(&directMessages)
+	} else {
+		err = q.Limit(uint(max)).All(&directMessages)
+	}
+	return
+}
+
+// Between returns a query matching the messages sent between two users in
+// either direction
+func Between(a, b bson.ObjectId) uppdb.Or {
+	return uppdb.Or{
+		uppdb.Cond{"senderId": a, "recipientId": b},
+		uppdb.Cond{"senderId": b, "recipientId": a},
+	}
+}
+
+// MarkRead marks every message matching the query as read in a single update
+// and drops them from the cache. Returns the ids of the messages that were
+// marked.
+func MarkRead(query interface{}) ([]bson.ObjectId, error) {
+	var rows []struct {
+		Id bson.ObjectId `bson:"_id"`
+	}
+	if err := collection.Find(query).Select("_id").All(&rows); err != nil {
+		return nil, err
+	}
+
+	ids := make([]bson.ObjectId, len(rows))
+	for i, row := range rows {
+		ids[i] = row.Id
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	if err := collection.Find(uppdb.Cond{"_id": bson.M{"$in": ids}}).Update(bson.M{
+		"read":    true,
+		"updated": time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		cache.Delete(string(id))
+	}
+	return ids, nil
+}
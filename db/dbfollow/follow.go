@@ -0,0 +1,157 @@
+package dbfollow
+
+import (
+	"hybris/db"
+	"sync"
+	"time"
+
+	gocache "github.com/pmylund/go-cache"
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+var (
+	collection  uppdb.Collection
+	cache       = gocache.New(db.CacheExpiration, db.CacheCleanupInterval)
+	getMutexes  = map[bson.ObjectId]*sync.Mutex{}
+	lockMutexes = map[bson.ObjectId]*sync.Mutex{}
+)
+
+func init() {
+	coll, err := db.Session.Collection("follows")
+	if err != nil && err != uppdb.ErrCollectionDoesNotExists {
+		panic(err)
+	}
+	collection = coll
+}
+
+type Follow struct {
+	// Database object id
+	Id bson.ObjectId `json:"id" bson:"_id"`
+
+	// User who follows
+	FollowerId bson.ObjectId `json:"followerId" bson:"followerId"`
+
+	// User being followed
+	FolloweeId bson.ObjectId `json:"followeeId" bson:"followeeId"`
+
+	// When the object was created
+	Created time.Time `json:"created" bson:"created"`
+
+	// When the object was last updated
+	Updated time.Time `json:"updated" bson:"updated"`
+}
+
+func New(followerId, followeeId bson.ObjectId) (Follow, error) {
+	return Follow{
+		Id:         bson.NewObjectId(),
+		FollowerId: followerId,
+		FolloweeId: followeeId,
+		Created:    time.Now(),
+		Updated:    time.Now(),
+	}, nil
+}
+
+func Get(query interface{}) (Follow, error) {
+	f, err := get(query)
+	if f == nil {
+		return Follow{}, err
+	}
+	return *f, err
+}
+
+func get(query interface{}) (*Follow, error) {
+	var follow *Follow
+	if err := collection.Find(query).One(&follow); err != nil {
+		return nil, err
+	}
+	return getId(follow.Id)
+}
+
+func GetId(id bson.ObjectId) (Follow, error) {
+	f, err := getId(id)
+	if f == nil {
+		return Follow{}, err
+	}
+	return *f, err
+}
+
+func getId(id bson.ObjectId) (*Follow, error) {
+	if _, ok := getMutexes[id]; !ok {
+		getMutexes[id] = &sync.Mutex{}
+	}
+
+	getMutexes[id].Lock()
+	defer getMutexes[id].Unlock()
+
+	if follow, found := cache.Get(string(id)); found {
+		return follow.(*Follow), nil
+	}
+
+	var follow *Follow
+
+	if err := collection.Find(uppdb.Cond{"_id": id}).One(&follow); err != nil {
+		return nil, err
+	}
+
+	cache.Set(string(id), follow, gocache.DefaultExpiration)
+
+	return follow, nil
+}
+
+func GetMulti(max int, query interface{}) (follows []Follow, err error) {
+	q := collection.Find(query)
+	if max < 0 {
+		err = q.All(&follows)
+	} else {
+		err = q.Limit(uint(max)).All(&follows)
+	}
+	return
+}
+
+func Lock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		lockMutexes[id] = &sync.Mutex{}
+	}
+
+	lockMutexes[id].Lock()
+}
+
+func Unlock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		return
+	}
+	lockMutexes[id].Unlock()
+}
+
+func LockGet(id bson.ObjectId) (*Follow, error) {
+	Lock(id)
+	return getId(id)
+}
+
+func (f Follow) Save() (err error) {
+	f.Updated = time.Now()
+	_, err = collection.Append(f)
+	return
+}
+
+func (f Follow) Delete() error {
+	cache.Delete(string(f.Id))
+	return collection.Find(uppdb.Cond{"_id": f.Id}).Remove()
+}
+
+// Extra methods
+
+// Followers returns the ids of every user following the given user
+func Followers(userId bson.ObjectId) ([]bson.ObjectId, error) {
+	follows, err := GetMulti(-1, uppdb.Cond{"followeeId": userId})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]bson.ObjectId, len(follows))
+	for i, f := range follows {
+		ids[i] = f.FollowerId
+	}
+	return ids, nil
+}
@@ -31,7 +31,8 @@ type GlobalBan struct {
 	// Database object id
 	Id bson.ObjectId `json:"id" bson:"_id"`
 
-	// User who was banned
+	// User who was banned. The misspelled key is kept so stored bans still
+	// match.
 	BanneeId bson.ObjectId `json:"baneeId" bson:"baneeId"`
 
 	// User who created this ban
@@ -152,3 +153,16 @@ func (gb GlobalBan) Delete() error {
 	cache.Delete(string(gb.Id))
 	return collection.Find(uppdb.Cond{"_id": gb.Id}).Remove()
 }
+
+// Extra methods
+
+// Banned checks whether a user has a global ban that hasn't expired
+func Banned(userId bson.ObjectId) (bool, error) {
+	globalBan, err := Get(uppdb.Cond{"baneeId": userId})
+	if err == uppdb.ErrNoMoreRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return globalBan.Until == nil || globalBan.Until.After(time.Now()), nil
+}
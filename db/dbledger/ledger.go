@@ -0,0 +1,179 @@
+package dbledger
+
+import (
+	"hybris/db"
+	"sync"
+	"time"
+
+	gocache "github.com/pmylund/go-cache"
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+var (
+	collection  uppdb.Collection
+	cache       = gocache.New(db.CacheExpiration, db.CacheCleanupInterval)
+	getMutexes  = map[bson.ObjectId]*sync.Mutex{}
+	lockMutexes = map[bson.ObjectId]*sync.Mutex{}
+)
+
+const collectionName = "ledger"
+
+func init() {
+	coll, err := db.Session.Collection(collectionName)
+	if err != nil && err != uppdb.ErrCollectionDoesNotExists {
+		panic(err)
+	}
+	collection = coll
+}
+
+type Entry struct {
+	// Database object id
+	Id bson.ObjectId `json:"id" bson:"_id"`
+
+	// User whose balance changed
+	UserId bson.ObjectId `json:"userId" bson:"userId"`
+
+	// Community the change was earned in, if any
+	CommunityId bson.ObjectId `json:"communityId,omitempty" bson:"communityId,omitempty"`
+
+	// Currency that changed
+	// See enums/Currencies
+	Currency int `json:"currency" bson:"currency"`
+
+	// Amount the balance changed by. Negative for spendings
+	Amount int `json:"amount" bson:"amount"`
+
+	// Why the balance changed
+	// See enums/LedgerReasons
+	Reason int `json:"reason" bson:"reason"`
+
+	// Balance of the user after the change
+	Balance int `json:"balance" bson:"balance"`
+
+	// When the object was created
+	Created time.Time `json:"created" bson:"created"`
+
+	// When the object was last updated
+	Updated time.Time `json:"updated" bson:"updated"`
+}
+
+func New(userId, communityId bson.ObjectId, currency, amount, reason, balance int) (Entry, error) {
+	return Entry{
+		Id:          bson.NewObjectId(),
+		UserId:      userId,
+		CommunityId: communityId,
+		Currency:    currency,
+		Amount:      amount,
+		Reason:      reason,
+		Balance:     balance,
+		Created:     time.Now(),
+		Updated:     time.Now(),
+	}, nil
+}
+
+func Get(query interface{}) (Entry, error) {
+	e, err := get(query)
+	if e == nil {
+		return Entry{}, err
+	}
+	return *e, err
+}
+
+func get(query interface{}) (*Entry, error) {
+	var entry *Entry
+	if err := collection.Find(query).One(&entry); err != nil {
+		return nil, err
+	}
+	return getId(entry.Id)
+}
+
+func GetId(id bson.ObjectId) (Entry, error) {
+	e, err := getId(id)
+	if e == nil {
+		return Entry{}, err
+	}
+	return *e, err
+}
+
+func getId(id bson.ObjectId) (*Entry, error) {
+	if _, ok := getMutexes[id]; !ok {
+		getMutexes[id] = &sync.Mutex{}
+	}
+
+	getMutexes[id].Lock()
+	defer getMutexes[id].Unlock()
+
+	if entry, found := cache.Get(string(id)); found {
+		return entry.(*Entry), nil
+	}
+
+	var entry *Entry
+
+	if err := collection.Find(uppdb.Cond{"_id": id}).One(&entry); err != nil {
+		return nil, err
+	}
+
+	cache.Set(string(id), entry, gocache.DefaultExpiration)
+
+	return entry, nil
+}
+
+func GetMulti(max int, query interface{}) (entries []Entry, err error) {
+	q := collection.Find(query)
+	if max < 0 {
+		err = q.All(&entries)
+	} else {
+		err = q.Limit(uint(max)).All(&entries)
+	}
+	return
+}
+
+func Lock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		lockMutexes[id] = &sync.Mutex{}
+	}
+
+	lockMutexes[id].Lock()
+}
+
+func Unlock(id bson.ObjectId) {
+	if _, ok := lockMutexes[id]; !ok {
+		return
+	}
+	lockMutexes[id].Unlock()
+}
+
+func LockGet(id bson.ObjectId) (*Entry, error) {
+	Lock(id)
+	return getId(id)
+}
+
+// Earner is the sum of the amounts in the entries of a user
+type Earner struct {
+	UserId bson.ObjectId `bson:"_id"`
+	Amount int           `bson:"amount"`
+}
+
+// TopEarners sums the amounts of the entries matching query per user and
+// returns the max users with the highest sums, highest first
+func TopEarners(max int, query bson.M) (earners []Earner, err error) {
+	err = db.Driver(collectionName).Pipe([]bson.M{
+		{"$match": query},
+		{"$group": bson.M{"_id": "$userId", "amount": bson.M{"$sum": "$amount"}}},
+		{"$sort": bson.M{"amount": -1}},
+		{"$limit": max},
+	}).All(&earners)
+	return
+}
+
+func (e Entry) Save() (err error) {
+	e.Updated = time.Now()
+	_, err = collection.Append(e)
+	return
+}
+
+func (e Entry) Delete() error {
+	cache.Delete(string(e.Id))
+	return collection.Find(uppdb.Cond{"_id": e.Id}).Remove()
+}
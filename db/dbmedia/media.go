@@ -2,8 +2,10 @@ package dbmedia
 
 import (
 	"errors"
+	"fmt"
 	"hybris/db"
 	"hybris/downloader"
+	"hybris/enums"
 	"hybris/structs"
 	"sync"
 	"time"
@@ -18,6 +20,10 @@ var (
 	cache       = gocache.New(db.CacheExpiration, db.CacheCleanupInterval)
 	getMutexes  = map[bson.ObjectId]*sync.Mutex{}
 	lockMutexes = map[bson.ObjectId]*sync.Mutex{}
+
+	// Serializes resolving the same source media
+	resolveMutex   sync.Mutex
+	resolveMutexes = map[string]*sync.Mutex{}
 )
 
 func init() {
@@ -77,7 +83,7 @@ type Media struct {
 }
 
 func New(mid string, platform int) (Media, error) {
-	if media, err := Get(uppdb.Cond{"mid": mid}); err != uppdb.ErrNoMoreRows {
+	if media, err := Get(uppdb.Cond{"type": platform, "mid": mid}); err != uppdb.ErrNoMoreRows {
 		return media, err
 	}
 
@@ -90,9 +96,9 @@ func New(mid string, platform int) (Media, error) {
 		err    error
 	)
 	switch platform {
-	case 0:
+	case enums.MediaTypes.Youtube:
 		image, artist, title, blurb, length, err = downloader.Youtube(mid)
-	case 1:
+	case enums.MediaTypes.Soundcloud:
 		image, artist, title, blurb, length, err = downloader.Soundcloud(mid)
 	default:
 		err = errors.New("Invalid type.")
@@ -116,6 +122,33 @@ func New(mid string, platform int) (Media, error) {
 	}, nil
 }
 
+// Resolve returns the stored media for the source media id on a platform. If
+// the media isn't stored yet, its info is downloaded and it is saved.
+func Resolve(mid string, platform int) (Media, error) {
+	key := fmt.Sprintf("%d:%s", platform, mid)
+
+	resolveMutex.Lock()
+	if _, ok := resolveMutexes[key]; !ok {
+		resolveMutexes[key] = &sync.Mutex{}
+	}
+	m := resolveMutexes[key]
+	resolveMutex.Unlock()
+
+	m.Lock()
+	defer m.Unlock()
+
+	if media, err := Get(uppdb.Cond{"type": platform, "mid": mid}); err != uppdb.ErrNoMoreRows {
+		return media, err
+	}
+
+	media, err := New(mid, platform)
+	if err != nil {
+		return Media{}, err
+	}
+
+	return media, media.Save()
+}
+
 func Get(query interface{}) (Media, error) {
 	m, err := get(query)
 	if m == nil {
@@ -228,3 +261,21 @@ func StructMulti(media []Media) (payload []structs.MediaInfo) {
 	}
 	return
 }
+
+// Extra methods
+
+// AddPlaylists changes the amount of playlists the media is in by n. The
+// count never goes below zero.
+func AddPlaylists(id bson.ObjectId, n int) error {
+	m, err := LockGet(id)
+	defer Unlock(id)
+	if err != nil {
+		return err
+	}
+
+	m.Playlists += n
+	if m.Playlists < 0 {
+		m.Playlists = 0
+	}
+	return m.Save()
+}
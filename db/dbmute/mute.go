@@ -36,7 +36,8 @@ type Mute struct {
 	MuteeId bson.ObjectId `json:"muteeId" bson:"muteeId"`
 
 	// User who created this mute
-	MuterId bson.ObjectId `json:"muterId" bson:"muterId"`
+	// Empty for mutes given automatically
+	MuterId bson.ObjectId `json:"muterId" bson:"muterId,omitempty"`
 
 	// Community this mute belongs to
 	CommunityId bson.ObjectId `json:"communityId" bson:"communityId"`
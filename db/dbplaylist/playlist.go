@@ -6,6 +6,7 @@ import (
 	"hybris/db/dbplaylistitem"
 	"hybris/structs"
 	"hybris/validation"
+	"sort"
 	"sync"
 	"time"
 
@@ -187,21 +188,39 @@ func (p Playlist) GetItems() (items []dbplaylistitem.PlaylistItem, err error) {
 	return
 }
 
+// AddItem appends a new item for the media to the end of the playlist
+func (p Playlist) AddItem(mediaId bson.ObjectId, title, artist string) (dbplaylistitem.PlaylistItem, error) {
+	items, err := p.GetItems()
+	if err != nil {
+		return dbplaylistitem.PlaylistItem{}, err
+	}
+
+	item, err := dbplaylistitem.New(p.Id, mediaId, title, artist)
+	if err != nil {
+		return dbplaylistitem.PlaylistItem{}, err
+	}
+
+	item.Order = len(items)
+	return item, item.Save()
+}
+
+// SaveItems saves the items in the order they're given, keeping the order of
+// the playlist dense
 func (p Playlist) SaveItems(items []dbplaylistitem.PlaylistItem) error {
-	for _, item := range items {
+	for _, item := range p.recalculateItems(items) {
 		if err := item.Save(); err != nil {
 			return err
 		}
+		dbplaylistitem.Uncache(item.Id)
 	}
 	return nil
 }
 
+// Sorts the items by their order. Holes and duplicates left by a failed save
+// are tolerated, and fixed by the next SaveItems.
 func (p Playlist) sorItems(items []dbplaylistitem.PlaylistItem) []dbplaylistitem.PlaylistItem {
-	payload := make([]dbplaylistitem.PlaylistItem, len(items))
-	for _, item := range items {
-		payload[item.Order] = item
-	}
-	return payload
+	sort.Stable(dbplaylistitem.ByOrder(items))
+	return items
 }
 
 func (p Playlist) recalculateItems(items []dbplaylistitem.PlaylistItem) (payload []dbplaylistitem.PlaylistItem) {
@@ -211,3 +230,17 @@ func (p Playlist) recalculateItems(items []dbplaylistitem.PlaylistItem) (payload
 	}
 	return
 }
+
+// Uncache drops the cached copy of a playlist so the next lookup reads it from
+// the database. Used when a playlist is saved from a copy that isn't the
+// cached one.
+func Uncache(id bson.ObjectId) {
+	cache.Delete(string(id))
+}
+
+// ByOrder sorts playlists by their position in the list of their owner
+type ByOrder []Playlist
+
+func (b ByOrder) Len() int           { return len(b) }
+func (b ByOrder) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b ByOrder) Less(i, j int) bool { return b[i].Order < b[j].Order }
@@ -176,3 +176,19 @@ func StructMulti(playlistItem []PlaylistItem) (payload []structs.PlaylistItem) {
 	}
 	return
 }
+
+// Extra methods
+
+// Uncache drops the cached copy of an item so the next lookup reads it from
+// the database. Used when an item is saved from a copy that isn't the cached
+// one.
+func Uncache(id bson.ObjectId) {
+	cache.Delete(string(id))
+}
+
+// ByOrder sorts items by their position in the playlist
+type ByOrder []PlaylistItem
+
+func (b ByOrder) Len() int           { return len(b) }
+func (b ByOrder) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b ByOrder) Less(i, j int) bool { return b[i].Order < b[j].Order }
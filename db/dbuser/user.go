@@ -6,6 +6,7 @@ import (
 	"hybris/db/dbplaylist"
 	"hybris/structs"
 	"hybris/validation"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -65,6 +66,10 @@ type User struct {
 	// User's preferred language
 	Locale string `json:"locale" bson:"locale"`
 
+	// Running totals over the plays of the user. Nil until they're first
+	// counted from the user history
+	Totals *PlayTotals `json:"totals" bson:"totals,omitempty"`
+
 	// When the object was created
 	Created time.Time `json:"created" bson:"created"`
 
@@ -72,6 +77,12 @@ type User struct {
 	Updated time.Time `json:"updated" bson:"updated"`
 }
 
+type PlayTotals struct {
+	Plays int `json:"plays" bson:"plays"`
+	Woots int `json:"woots" bson:"woots"`
+	Mehs  int `json:"mehs" bson:"mehs"`
+}
+
 func New(username string) (User, error) {
 	displayName := username
 	username = strings.ToLower(username)
@@ -221,21 +232,23 @@ func (u User) GetPlaylists() (playlists []dbplaylist.Playlist, err error) {
 	return
 }
 
+// SavePlaylists saves the playlists in the order they're given, keeping the
+// order of the playlists dense
 func (u User) SavePlaylists(playlists []dbplaylist.Playlist) error {
-	for _, playlist := range playlists {
+	for _, playlist := range u.recalculateItems(playlists) {
 		if err := playlist.Save(); err != nil {
 			return err
 		}
+		dbplaylist.Uncache(playlist.Id)
 	}
 	return nil
 }
 
+// Sorts the playlists by their order. Holes and duplicates left by a failed
+// save are tolerated, and fixed by the next SavePlaylists.
 func (u User) sorPlaylists(playlists []dbplaylist.Playlist) []dbplaylist.Playlist {
-	payload := make([]dbplaylist.Playlist, len(playlists))
-	for _, playlist := range playlists {
-		payload[playlist.Order] = playlist
-	}
-	return payload
+	sort.Stable(dbplaylist.ByOrder(playlists))
+	return playlists
 }
 
 func (u User) recalculateItems(playlists []dbplaylist.Playlist) (payload []dbplaylist.Playlist) {
@@ -245,3 +258,9 @@ func (u User) recalculateItems(playlists []dbplaylist.Playlist) (payload []dbpla
 	}
 	return
 }
+
+// GetTop returns the users with the most points first
+func GetTop(max int) (users []User, err error) {
+	err = collection.Find().Sort("-points").Limit(uint(max)).All(&users)
+	return
+}
@@ -2,6 +2,9 @@ package dbuserhistory
 
 import (
 	"hybris/db"
+	"hybris/db/dbmedia"
+	"hybris/db/dbuser"
+	"hybris/structs"
 	"sync"
 	"time"
 
@@ -160,3 +163,69 @@ func (uh UserHistory) Save() (err error) {
 func (uh UserHistory) Delete() error {
 	return collection.Find(uppdb.Cond{"_id": uh.Id}).Remove()
 }
+
+func (uh UserHistory) Struct() structs.HistoryItem {
+	media, err := dbmedia.GetId(uh.MediaId)
+	if err != nil {
+		return structs.HistoryItem{}
+	}
+	dj, err := dbuser.GetId(uh.UserId)
+	if err != nil {
+		return structs.HistoryItem{}
+	}
+	return structs.HistoryItem{
+		Id:   uh.Id,
+		Time: uh.Created,
+		Dj:   dj.Struct(),
+		Media: structs.ResolvedMediaInfo{
+			media.Struct(),
+			uh.Artist,
+			uh.Title,
+		},
+		Votes: structs.VoteCount{
+			Woot: uh.Woots,
+			Meh:  uh.Mehs,
+			Save: uh.Saves,
+		},
+	}
+}
+
+func StructMulti(userHistory []UserHistory) (payload []structs.HistoryItem) {
+	for _, uh := range userHistory {
+		payload = append(payload, uh.Struct())
+	}
+	return
+}
+
+// Extra methods
+
+// GetRecent finds plays of the user history starting from the one that ended
+// last, for the recent plays shown on profiles
+func GetRecent(max int, query interface{}) (userHistory []UserHistory, err error) {
+	q := collection.Find(query).Sort("-created")
+	if max < 0 {
+		err = q.All(&userHistory)
+	} else {
+		err = q.Limit(uint(max)).All(&userHistory)
+	}
+	return
+}
+
+// Totals counts the plays of a user and the woots and mehs they got. Only the
+// vote counts are read from each play.
+func Totals(userId bson.ObjectId) (totals dbuser.PlayTotals, err error) {
+	var rows []struct {
+		Woots int `bson:"woots"`
+		Mehs  int `bson:"mehs"`
+	}
+	if err = collection.Find(uppdb.Cond{"userId": userId}).Select("woots", "mehs").All(&rows); err != nil {
+		return
+	}
+
+	totals.Plays = len(rows)
+	for _, row := range rows {
+		totals.Woots += row.Woots
+		totals.Mehs += row.Mehs
+	}
+	return
+}
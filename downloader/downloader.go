@@ -0,0 +1,20 @@
+package downloader
+
+import (
+	"strings"
+)
+
+const (
+	searchLimit = 20
+	importLimit = 500
+)
+
+// Splits a media title in the form of "artist - title". If the title can't
+// be split, fallback is used as the artist.
+func splitTitle(full, fallback string) (artist, title string) {
+	strSplit := strings.Split(full, " - ")
+	if len(strSplit) > 1 {
+		return strSplit[0], strings.Join(strSplit[1:], " - ")
+	}
+	return fallback, full
+}
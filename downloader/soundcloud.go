@@ -4,10 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"hybris/debug"
+	"hybris/enums"
+	"hybris/structs"
 	"net/http"
-	"strings"
+	"net/url"
+	"strconv"
 )
 
+const soundcloudClientId = "fddfcd9f79c36f4716b4f7ab1664cd8d"
+
 func Soundcloud(id string) (string, string, string, string, int, error) {
 	debug.Log("Downloading media info for %s from soundcloud", id)
 	var out struct {
@@ -20,7 +25,7 @@ func Soundcloud(id string) (string, string, string, string, int, error) {
 		} `json:"user"`
 	}
 
-	res, err := http.Get("https://api.soundcloud.com/tracks/" + id + "?client_id=fddfcd9f79c36f4716b4f7ab1664cd8d")
+	res, err := http.Get("https://api.soundcloud.com/tracks/" + id + "?client_id=" + soundcloudClientId)
 	if err != nil {
 		debug.Log("Failed to retrieve media info for %s from soundcloud: %s", id, err.Error())
 		return "", "", "", "", 0, err
@@ -50,13 +55,7 @@ func Soundcloud(id string) (string, string, string, string, int, error) {
 	blurb = out.Description
 	length = out.Length / 1000
 
-	strSplit := strings.Split(title, " - ")
-	if len(strSplit) > 1 {
-		artist = strSplit[0]
-		title = strings.Join(strSplit[1:], " - ")
-	} else {
-		artist = out.User.Username
-	}
+	artist, title = splitTitle(title, out.User.Username)
 
 	if len(blurb) > 400 {
 		blurb = blurb[:397] + "..."
@@ -65,3 +64,96 @@ func Soundcloud(id string) (string, string, string, string, int, error) {
 	debug.Log("Successfully downloaded media info for %s from soundcloud", id)
 	return image, artist, title, blurb, length, nil
 }
+
+func SoundcloudSearch(query string) ([]structs.SearchResult, error) {
+	debug.Log("Searching soundcloud for %s", query)
+	var out []struct {
+		Id    int    `json:"id"`
+		Image string `json:"artwork_url"`
+		Title string `json:"title"`
+		User  struct {
+			Username string `json:"username"`
+		} `json:"user"`
+	}
+
+	res, err := http.Get("https://api.soundcloud.com/tracks?limit=" + strconv.Itoa(searchLimit) +
+		"&q=" + url.QueryEscape(query) + "&client_id=" + soundcloudClientId)
+	if err != nil {
+		debug.Log("Failed to search soundcloud for %s: %s", query, err.Error())
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		debug.Log("Failed to search soundcloud for %s: Expected response code 200, received %d",
+			query, res.StatusCode)
+		return nil, errors.New("Failed to search media")
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		debug.Log("Failed to unmarshal json response for search %s from soundcloud: %s", query, err.Error())
+		return nil, err
+	}
+
+	results := []structs.SearchResult{}
+	for _, track := range out {
+		artist, title := splitTitle(track.Title, track.User.Username)
+		results = append(results, structs.SearchResult{
+			Image:   track.Image,
+			Artist:  artist,
+			Title:   title,
+			Type:    enums.MediaTypes.Soundcloud,
+			MediaId: strconv.Itoa(track.Id),
+		})
+	}
+
+	debug.Log("Successfully searched soundcloud for %s", query)
+	return results, nil
+}
+
+// SoundcloudSet returns the ids of every track in the set at the url
+func SoundcloudSet(setUrl string) ([]string, error) {
+	debug.Log("Downloading set %s from soundcloud", setUrl)
+	var out struct {
+		Kind   string `json:"kind"`
+		Tracks []struct {
+			Id int `json:"id"`
+		} `json:"tracks"`
+	}
+
+	res, err := http.Get("https://api.soundcloud.com/resolve?url=" + url.QueryEscape(setUrl) +
+		"&client_id=" + soundcloudClientId)
+	if err != nil {
+		debug.Log("Failed to retrieve set %s from soundcloud: %s", setUrl, err.Error())
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		debug.Log("Failed to retrieve set %s from soundcloud: Expected response code 200, received %d",
+			setUrl, res.StatusCode)
+		return nil, errors.New("Failed to get set")
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		debug.Log("Failed to unmarshal json response for set %s from soundcloud: %s", setUrl, err.Error())
+		return nil, err
+	}
+
+	if out.Kind != "playlist" {
+		debug.Log("Soundcloud url %s is not a set", setUrl)
+		return nil, errors.New("Url is not a set")
+	}
+
+	ids := []string{}
+	for _, track := range out.Tracks {
+		ids = append(ids, strconv.Itoa(track.Id))
+	}
+
+	if len(ids) > importLimit {
+		ids = ids[:importLimit]
+	}
+
+	debug.Log("Successfully downloaded set %s from soundcloud", setUrl)
+	return ids, nil
+}
@@ -3,6 +3,8 @@ package downloader
 import (
 	"errors"
 	"hybris/debug"
+	"hybris/enums"
+	"hybris/structs"
 	"net/http"
 	"strings"
 	"time"
@@ -55,13 +57,7 @@ func Youtube(id string) (string, string, string, string, int, error) {
 	title = item.Snippet.Title
 	blurb = item.Snippet.Description
 
-	strSplit := strings.Split(title, " - ")
-	if len(strSplit) > 1 {
-		artist = strSplit[0]
-		title = strings.Join(strSplit[1:], " - ")
-	} else {
-		artist = item.Snippet.ChannelTitle
-	}
+	artist, title = splitTitle(title, item.Snippet.ChannelTitle)
 
 	if len(blurb) > 400 {
 		blurb = blurb[:397] + "..."
@@ -77,3 +73,74 @@ func Youtube(id string) (string, string, string, string, int, error) {
 	debug.Log("Successfully downloaded media info for %s from youtube", id)
 	return image, artist, title, blurb, length, nil
 }
+
+func YoutubeSearch(query string) ([]structs.SearchResult, error) {
+	debug.Log("Searching youtube for %s", query)
+	searchCall := ytService.Search.List("snippet").
+		Q(query).
+		Type("video").
+		MaxResults(searchLimit)
+	searchResponse, err := searchCall.Do()
+	if err != nil {
+		debug.Log("Failed to search youtube for %s: %s", query, err.Error())
+		return nil, err
+	}
+
+	results := []structs.SearchResult{}
+	for _, item := range searchResponse.Items {
+		if item.Id == nil || item.Snippet == nil {
+			continue
+		}
+
+		artist, title := splitTitle(item.Snippet.Title, item.Snippet.ChannelTitle)
+		results = append(results, structs.SearchResult{
+			Image:   "https://img.youtube.com/vi/" + item.Id.VideoId + "/hqdefault.jpg",
+			Artist:  artist,
+			Title:   title,
+			Type:    enums.MediaTypes.Youtube,
+			MediaId: item.Id.VideoId,
+		})
+	}
+
+	debug.Log("Successfully searched youtube for %s", query)
+	return results, nil
+}
+
+// YoutubePlaylist returns the ids of every video in the playlist
+func YoutubePlaylist(id string) ([]string, error) {
+	debug.Log("Downloading playlist %s from youtube", id)
+	ids := []string{}
+	pageToken := ""
+	for {
+		itemsCall := ytService.PlaylistItems.List("contentDetails").
+			PlaylistId(id).
+			MaxResults(50)
+		if pageToken != "" {
+			itemsCall = itemsCall.PageToken(pageToken)
+		}
+
+		itemsResponse, err := itemsCall.Do()
+		if err != nil {
+			debug.Log("Failed to download playlist %s from youtube: %s", id, err.Error())
+			return nil, err
+		}
+
+		for _, item := range itemsResponse.Items {
+			if item.ContentDetails != nil {
+				ids = append(ids, item.ContentDetails.VideoId)
+			}
+		}
+
+		pageToken = itemsResponse.NextPageToken
+		if pageToken == "" || len(ids) >= importLimit {
+			break
+		}
+	}
+
+	if len(ids) > importLimit {
+		ids = ids[:importLimit]
+	}
+
+	debug.Log("Successfully downloaded playlist %s from youtube", id)
+	return ids, nil
+}
@@ -0,0 +1,116 @@
+package economy
+
+import (
+	"flag"
+	"hybris/db/dbledger"
+	"hybris/db/dbuser"
+	"hybris/debug"
+	"hybris/enums"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	// Points a DJ earns for every woot on their media
+	WootPoints = 1
+
+	// Most points a DJ can earn from a single media
+	SongCap = 50
+
+	// Points a listener earns for every ListenInterval spent listening
+	ListenPoints = 1
+
+	// Listening time it takes to earn ListenPoints
+	ListenInterval = 5 * time.Minute
+
+	// Woots from others a media needs for its DJ to earn SongDiamonds
+	DiamondWoots = 25
+
+	// Diamonds a DJ earns for media that reaches DiamondWoots
+	SongDiamonds = 1
+)
+
+func init() {
+	flag.IntVar(&WootPoints, "wootpoints", WootPoints, "Points a DJ earns for every woot")
+	flag.IntVar(&SongCap, "songcap", SongCap, "Most points a DJ can earn from a single media")
+	flag.IntVar(&ListenPoints, "listenpoints", ListenPoints, "Points a listener earns every listen interval")
+	flag.DurationVar(&ListenInterval, "listeninterval", ListenInterval, "Listening time it takes to earn listen points")
+	flag.IntVar(&DiamondWoots, "diamondwoots", DiamondWoots, "Woots a media needs for its DJ to earn diamonds")
+	flag.IntVar(&SongDiamonds, "songdiamonds", SongDiamonds, "Diamonds a DJ earns for media that reaches the diamond woots")
+}
+
+// DjPoints returns the points a DJ earns for the woots on their media
+func DjPoints(djId bson.ObjectId, woots []bson.ObjectId) int {
+	points := wootsFromOthers(djId, woots) * WootPoints
+	if points > SongCap {
+		points = SongCap
+	}
+	return points
+}
+
+// DjDiamonds returns the diamonds a DJ earns for the woots on their media
+func DjDiamonds(djId bson.ObjectId, woots []bson.ObjectId) int {
+	if DiamondWoots <= 0 || wootsFromOthers(djId, woots) < DiamondWoots {
+		return 0
+	}
+	return SongDiamonds
+}
+
+// ListenRewards returns the points earned for an amount of listening time,
+// and the time left over towards the next points
+func ListenRewards(listened time.Duration) (int, time.Duration) {
+	if ListenInterval <= 0 || ListenPoints <= 0 {
+		return 0, 0
+	}
+
+	intervals := listened / ListenInterval
+	return int(intervals) * ListenPoints, listened - intervals*ListenInterval
+}
+
+// The DJ wooting their own media doesn't count
+func wootsFromOthers(djId bson.ObjectId, woots []bson.ObjectId) int {
+	count := 0
+	for _, id := range woots {
+		if id != djId {
+			count++
+		}
+	}
+	return count
+}
+
+// Award changes a balance of a user and records the change in the ledger.
+// Returns the new balance.
+func Award(userId, communityId bson.ObjectId, currency, amount, reason int) (int, error) {
+	user, err := dbuser.LockGet(userId)
+	defer dbuser.Unlock(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	balance := &user.Points
+	if currency == enums.Currencies.Diamonds {
+		balance = &user.Diamonds
+	}
+
+	// The ledger entry is written first so a balance never changes without one
+	entry, err := dbledger.New(userId, communityId, currency, amount, reason, *balance+amount)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := entry.Save(); err != nil {
+		return 0, err
+	}
+
+	*balance += amount
+	if err := user.Save(); err != nil {
+		*balance -= amount
+		if err := entry.Delete(); err != nil {
+			debug.Log("Failed to remove ledger entry %s for unsaved balance of %s: %s", entry.Id, userId, err.Error())
+		}
+		return 0, err
+	}
+
+	return *balance, nil
+}
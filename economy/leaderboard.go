@@ -0,0 +1,75 @@
+package economy
+
+import (
+	"flag"
+	"hybris/db/dbledger"
+	"hybris/db/dbuser"
+	"hybris/enums"
+	"hybris/structs"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Amount of users on a leaderboard
+const leaderboardSize = 50
+
+// How far back community leaderboards look
+var LeaderboardPeriod = 30 * 24 * time.Hour
+
+func init() {
+	flag.DurationVar(&LeaderboardPeriod, "leaderboardperiod", LeaderboardPeriod, "How far back community leaderboards look")
+}
+
+// Leaderboard ranks users by their points. Global rankings use the balance of
+// every user. Community rankings use the points earned in that community
+// during the last LeaderboardPeriod.
+func Leaderboard(communityId bson.ObjectId) ([]structs.LeaderboardEntry, error) {
+	if communityId == "" {
+		return globalLeaderboard()
+	}
+	return communityLeaderboard(communityId)
+}
+
+func globalLeaderboard() ([]structs.LeaderboardEntry, error) {
+	users, err := dbuser.GetTop(leaderboardSize)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]structs.LeaderboardEntry, len(users))
+	for i, user := range users {
+		payload[i] = structs.LeaderboardEntry{
+			User:   user.Struct(),
+			Rank:   i + 1,
+			Points: user.Points,
+		}
+	}
+	return payload, nil
+}
+
+func communityLeaderboard(communityId bson.ObjectId) ([]structs.LeaderboardEntry, error) {
+	earners, err := dbledger.TopEarners(leaderboardSize, bson.M{
+		"communityId": communityId,
+		"currency":    enums.Currencies.Points,
+		"created":     bson.M{"$gte": time.Now().Add(-LeaderboardPeriod)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]structs.LeaderboardEntry, len(earners))
+	for i, earner := range earners {
+		user, err := dbuser.GetId(earner.UserId)
+		if err != nil {
+			return nil, err
+		}
+
+		payload[i] = structs.LeaderboardEntry{
+			User:   user.Struct(),
+			Rank:   i + 1,
+			Points: earner.Amount,
+		}
+	}
+	return payload, nil
+}
@@ -0,0 +1,9 @@
+package enums
+
+var Currencies = struct {
+	Points,
+	Diamonds int
+}{
+	Points:   0,
+	Diamonds: 1,
+}
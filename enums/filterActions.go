@@ -0,0 +1,11 @@
+package enums
+
+var FilterActions = struct {
+	Block,
+	Mask,
+	Mute int
+}{
+	Block: 0,
+	Mask:  1,
+	Mute:  2,
+}
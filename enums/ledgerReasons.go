@@ -0,0 +1,11 @@
+package enums
+
+var LedgerReasons = struct {
+	Woot,
+	Listen,
+	PopularMedia int
+}{
+	Woot:         0,
+	Listen:       1,
+	PopularMedia: 2,
+}
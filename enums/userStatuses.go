@@ -0,0 +1,13 @@
+package enums
+
+var UserStatuses = struct {
+	Offline,
+	Online,
+	Away,
+	Busy string
+}{
+	Offline: "offline",
+	Online:  "online",
+	Away:    "away",
+	Busy:    "busy",
+}
@@ -0,0 +1,11 @@
+package enums
+
+var VoteTypes = struct {
+	Woot,
+	Meh,
+	Save int
+}{
+	Woot: 0,
+	Meh:  1,
+	Save: 2,
+}
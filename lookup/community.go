@@ -0,0 +1,121 @@
+package lookup
+
+import (
+	"hybris/db/dbcommunity"
+	"hybris/db/dbcommunityhistory"
+	"hybris/db/dbcommunitystaff"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/structs"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func CommunityInfo(id bson.ObjectId) (int, interface{}) {
+	communityData, err := dbcommunity.GetId(id)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, communityData.Struct()
+}
+
+func CommunityState(id bson.ObjectId) (int, interface{}) {
+	communityData, err := dbcommunity.GetId(id)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	community := realtime.NewCommunity(communityData.Id)
+	community.Lock()
+	defer community.Unlock()
+
+	return enums.ResponseCodes.Ok, community.GetState()
+}
+
+// HistoryQuery filters and pages community history. Zero values don't filter.
+type HistoryQuery struct {
+	// Only plays older than this one are returned
+	Before bson.ObjectId `json:"before"`
+
+	// Only plays started within this range are returned
+	From *time.Time `json:"from"`
+	To   *time.Time `json:"to"`
+
+	// Only plays by this DJ are returned
+	DjId bson.ObjectId `json:"djId"`
+
+	// Only plays of this media are returned
+	MediaId bson.ObjectId `json:"mediaId"`
+
+	// Maximum amount of plays returned. Defaults to and is capped at 50.
+	Limit int `json:"limit"`
+}
+
+func CommunityHistory(id bson.ObjectId, query HistoryQuery) (int, interface{}) {
+	if _, err := dbcommunity.GetId(id); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	cond := uppdb.Cond{"communityId": id}
+	if query.Before != "" {
+		if !query.Before.Valid() {
+			return enums.ResponseCodes.BadRequest, nil
+		}
+		cond["_id"] = bson.M{"$lt": query.Before}
+	}
+
+	created := bson.M{}
+	if query.From != nil {
+		created["$gte"] = *query.From
+	}
+	if query.To != nil {
+		created["$lte"] = *query.To
+	}
+	if len(created) > 0 {
+		cond["created"] = created
+	}
+
+	if query.DjId != "" {
+		cond["userId"] = query.DjId
+	}
+
+	if query.MediaId != "" {
+		cond["mediaId"] = query.MediaId
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > 50 {
+		limit = 50
+	}
+
+	history, err := dbcommunityhistory.GetRecent(limit, cond)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, append([]structs.HistoryItem{}, dbcommunityhistory.StructMulti(history)...)
+}
+
+func CommunityStaff(id bson.ObjectId) (int, interface{}) {
+	if _, err := dbcommunity.GetId(id); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	staff, err := dbcommunitystaff.GetMulti(50, uppdb.Cond{"communityId": id})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, dbcommunitystaff.StructMulti(staff)
+}
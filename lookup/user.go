@@ -0,0 +1,93 @@
+package lookup
+
+import (
+	"hybris/db/dbcommunity"
+	"hybris/db/dbcommunitystaff"
+	"hybris/db/dbuser"
+	"hybris/db/dbuserhistory"
+	"hybris/enums"
+	"hybris/structs"
+	"strings"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func UserProfile(username string) (int, interface{}) {
+	user, err := dbuser.Get(uppdb.Cond{"username": strings.ToLower(strings.TrimSpace(username))})
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// Only public info is exposed. Never use UserPrivateInfo here.
+	profile := structs.UserProfile{
+		UserInfo: user.Struct(),
+		History:  []structs.HistoryItem{},
+		Hosted:   []structs.CommunityInfo{},
+		Staff:    []structs.StaffRole{},
+	}
+
+	totals, err := playTotals(user.Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	profile.Plays = totals.Plays
+	profile.Woots = totals.Woots
+	profile.Mehs = totals.Mehs
+
+	recent, err := dbuserhistory.GetRecent(20, uppdb.Cond{"userId": user.Id})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+	profile.History = append(profile.History, dbuserhistory.StructMulti(recent)...)
+
+	hosted, err := dbcommunity.GetMulti(-1, uppdb.Cond{"hostId": user.Id})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+	profile.Hosted = append(profile.Hosted, dbcommunity.StructMulti(hosted)...)
+
+	staff, err := dbcommunitystaff.GetMulti(-1, uppdb.Cond{"userId": user.Id})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	for _, s := range staff {
+		profile.Staff = append(profile.Staff, structs.StaffRole{
+			CommunityId: s.CommunityId,
+			Role:        s.Role,
+		})
+	}
+
+	return enums.ResponseCodes.Ok, profile
+}
+
+// Returns the play totals of a user, counting them from the user history the
+// first time. The user is locked while counting, like when a play is recorded,
+// so no play is counted twice or missed.
+func playTotals(id bson.ObjectId) (dbuser.PlayTotals, error) {
+	user, err := dbuser.LockGet(id)
+	defer dbuser.Unlock(id)
+	if err != nil {
+		return dbuser.PlayTotals{}, err
+	}
+
+	if user.Totals != nil {
+		return *user.Totals, nil
+	}
+
+	totals, err := dbuserhistory.Totals(id)
+	if err != nil {
+		return dbuser.PlayTotals{}, err
+	}
+
+	user.Totals = &totals
+	if err := user.Save(); err != nil {
+		user.Totals = nil
+		return dbuser.PlayTotals{}, err
+	}
+	return totals, nil
+}
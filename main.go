@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"hybris/realtime"
 	"hybris/routes"
 	"log"
 	"net/http"
@@ -16,6 +17,9 @@ func main() {
 	flag.Parse()
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
+	realtime.Restore()
+	realtime.RewardListeners()
+
 	router := pat.New()
 	routes.Attach(router)
 
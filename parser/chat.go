@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	mentionPattern = regexp.MustCompile(`(?:^|\s)@([A-Za-z0-9._-]{2,21})`)
+	linkPattern    = regexp.MustCompile(`https?://[^\s<>"']+`)
+)
+
+// Message is a chat message broken down into its parts
+type Message struct {
+	// Message without the /me command
+	Text string
+
+	// Whether or not the message started with /me
+	Me bool
+
+	// Lowercased usernames mentioned with @, without duplicates
+	Mentions []string
+
+	// Http and https links, without duplicates
+	Links []string
+}
+
+// Chat parses the /me command, mentions and links of a chat message
+func Chat(message string) Message {
+	m := Message{
+		Text:     message,
+		Mentions: []string{},
+		Links:    []string{},
+	}
+
+	if strings.HasPrefix(message, "/me ") {
+		m.Me = true
+		m.Text = strings.TrimSpace(strings.TrimPrefix(message, "/me "))
+	}
+
+	found := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(m.Text, -1) {
+		// Trailing dots usually end the sentence rather than the username
+		username := strings.ToLower(strings.TrimRight(match[1], "."))
+		if len(username) >= 2 && !found[username] {
+			found[username] = true
+			m.Mentions = append(m.Mentions, username)
+		}
+	}
+
+	found = map[string]bool{}
+	for _, match := range linkPattern.FindAllString(m.Text, -1) {
+		link := strings.TrimRight(match, ".,;:!?)")
+		if u, err := url.Parse(link); err != nil || u.Host == "" {
+			continue
+		}
+		if !found[link] {
+			found[link] = true
+			m.Links = append(m.Links, link)
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChatMe(t *testing.T) {
+	tests := []struct {
+		message string
+		text    string
+		me      bool
+	}{
+		{"hello", "hello", false},
+		{"/me waves", "waves", true},
+		{"/me   waves  ", "waves", true},
+		{"/mewaves", "/mewaves", false},
+		{"say /me waves", "say /me waves", false},
+	}
+
+	for _, test := range tests {
+		m := Chat(test.message)
+		if m.Text != test.text || m.Me != test.me {
+			t.Errorf("Chat(%q) = %q, %t, want %q, %t", test.message, m.Text, m.Me, test.text, test.me)
+		}
+	}
+}
+
+func TestChatMentions(t *testing.T) {
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"no mentions", []string{}},
+		{"@alice hi", []string{"alice"}},
+		{"hi @Alice and @bob", []string{"alice", "bob"}},
+		{"@alice @ALICE @alice", []string{"alice"}},
+		{"thanks @bob.", []string{"bob"}},
+		{"mail me at carol@example.com", []string{}},
+		{"@a is too short", []string{}},
+		{"/me pokes @dave", []string{"dave"}},
+	}
+
+	for _, test := range tests {
+		if got := Chat(test.message).Mentions; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Chat(%q) mentions = %q, want %q", test.message, got, test.want)
+		}
+	}
+}
+
+func TestChatLinks(t *testing.T) {
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"no links", []string{}},
+		{"see https://example.com", []string{"https://example.com"}},
+		{"see http://example.com/a?b=c.", []string{"http://example.com/a?b=c"}},
+		{"(https://example.com)", []string{"https://example.com"}},
+		{"https://a.com https://b.com https://a.com", []string{"https://a.com", "https://b.com"}},
+		{"ftp://example.com", []string{}},
+		{"https:// nothing", []string{}},
+	}
+
+	for _, test := range tests {
+		if got := Chat(test.message).Links; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Chat(%q) links = %q, want %q", test.message, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"bytes"
+	"hybris/enums"
+	"hybris/structs"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Most compiled patterns kept around. Rules edited away stay in the cache,
+// so it's emptied when it fills up.
+const maxCompiled = 1000
+
+var (
+	compiled      = map[structs.ChatFilterRule]*regexp.Regexp{}
+	compiledMutex sync.Mutex
+)
+
+// FilterPattern compiles the pattern of a filter rule. Matching is case
+// insensitive. Whole word rules compile to the quoted word alone and their
+// word boundaries are checked by Filter.
+func FilterPattern(rule structs.ChatFilterRule) (*regexp.Regexp, error) {
+	compiledMutex.Lock()
+	defer compiledMutex.Unlock()
+
+	if re, ok := compiled[rule]; ok {
+		return re, nil
+	}
+
+	pattern := rule.Pattern
+	if !rule.Regex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+
+	re, err := regexp.Compile(`(?i)` + pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(compiled) >= maxCompiled {
+		compiled = map[structs.ChatFilterRule]*regexp.Regexp{}
+	}
+	compiled[rule] = re
+	return re, nil
+}
+
+// Filter applies filter rules to a chat message. Matches of mask rules are
+// replaced with asterisks. Returns the filtered text and the strictest action
+// of the rules that matched, or -1 if none did.
+func Filter(text string, rules []structs.ChatFilterRule) (string, int) {
+	action := -1
+	for _, rule := range rules {
+		re, err := FilterPattern(rule)
+		if err != nil {
+			continue
+		}
+
+		var matches [][]int
+		if rule.Regex {
+			matches = re.FindAllStringIndex(text, -1)
+		} else {
+			matches = wholeWords(re, text)
+		}
+		if len(matches) <= 0 {
+			continue
+		}
+
+		if rule.Action == enums.FilterActions.Mask {
+			text = mask(text, matches)
+		}
+
+		if strictness(rule.Action) > strictness(action) {
+			action = rule.Action
+		}
+	}
+	return text, action
+}
+
+// Finds the matches of a word that aren't part of a longer word. Words are
+// made of letters, numbers and underscores of any script, like the
+// (^|[^\p{L}\p{N}_])word($|[^\p{L}\p{N}_]) pattern. The boundaries are
+// checked here instead of in the pattern so they aren't consumed, which would
+// stop a word right after another from matching.
+func wholeWords(re *regexp.Regexp, text string) [][]int {
+	matches := [][]int{}
+	for pos := 0; pos < len(text); {
+		loc := re.FindStringIndex(text[pos:])
+		if loc == nil || loc[1] == loc[0] {
+			break
+		}
+
+		start, end := pos+loc[0], pos+loc[1]
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			matches = append(matches, []int{start, end})
+			pos = end
+			continue
+		}
+
+		// The match may still start further into this one
+		_, size := utf8.DecodeRuneInString(text[start:])
+		pos = start + size
+	}
+	return matches
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_'
+}
+
+// Replaces every rune of the matched ranges with an asterisk
+func mask(text string, matches [][]int) string {
+	var b bytes.Buffer
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m[0]])
+		b.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[m[0]:m[1]])))
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func strictness(action int) int {
+	switch action {
+	case enums.FilterActions.Mute:
+		return 3
+	case enums.FilterActions.Block:
+		return 2
+	case enums.FilterActions.Mask:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"hybris/enums"
+	"hybris/structs"
+	"testing"
+)
+
+func TestFilterWholeWords(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    string
+	}{
+		// ASCII words
+		{"bad", "bad", "***"},
+		{"bad", "this is bad.", "this is ***."},
+		{"bad", "badge", "badge"},
+		{"bad", "not_bad", "not_bad"},
+		{"bad", "BAD bad Bad", "*** *** ***"},
+		{"bad", "bad,bad", "***,***"},
+
+		// Words in other scripts
+		{"плохо", "это плохо!", "это *****!"},
+		{"плохо", "неплохо", "неплохо"},
+		{"плохо", "плохой", "плохой"},
+		{"ダメ", "ダメ です", "** です"},
+
+		// Patterns starting or ending with punctuation
+		{"f*ck", "what the f*ck", "what the ****"},
+		{"f*ck", "f*cking", "f*cking"},
+		{"!!!", "stop !!!", "stop ***"},
+		{"#tag", "see #tag here", "see **** here"},
+		{"#tag", "see a#tag here", "see a#tag here"},
+		{"c++", "i like c++", "i like ***"},
+		{"c++", "i like c++x", "i like c++x"},
+	}
+
+	for _, test := range tests {
+		rule := structs.ChatFilterRule{Pattern: test.pattern, Action: enums.FilterActions.Mask}
+		got, _ := Filter(test.text, []structs.ChatFilterRule{rule})
+		if got != test.want {
+			t.Errorf("Filter(%q) with %q = %q, want %q", test.text, test.pattern, got, test.want)
+		}
+	}
+}
+
+func TestFilterActions(t *testing.T) {
+	rules := []structs.ChatFilterRule{
+		{Pattern: "mask", Action: enums.FilterActions.Mask},
+		{Pattern: "block", Action: enums.FilterActions.Block},
+		{Pattern: "mute", Action: enums.FilterActions.Mute},
+	}
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"clean", -1},
+		{"masked", -1},
+		{"mask", enums.FilterActions.Mask},
+		{"mask block", enums.FilterActions.Block},
+		{"mute mask block", enums.FilterActions.Mute},
+	}
+
+	for _, test := range tests {
+		if _, got := Filter(test.text, rules); got != test.want {
+			t.Errorf("Filter(%q) action = %d, want %d", test.text, got, test.want)
+		}
+	}
+}
+
+func TestFilterRegex(t *testing.T) {
+	rule := structs.ChatFilterRule{Pattern: `b+a+d+`, Regex: true, Action: enums.FilterActions.Mask}
+	got, action := Filter("so baaaad, badge", []structs.ChatFilterRule{rule})
+	if got != "so ******, ***ge" || action != enums.FilterActions.Mask {
+		t.Errorf("Filter = %q, %d", got, action)
+	}
+}
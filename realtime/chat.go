@@ -0,0 +1,128 @@
+package realtime
+
+import (
+	"hybris/debug"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// Used when a community has no chat limit set
+	defaultChatLimit       = 5
+	defaultChatLimitPeriod = 10
+
+	// Sending the same message again within this window counts as spam
+	duplicateWindow = 30 * time.Second
+
+	// Offenses are forgotten after this long without a new one
+	offenseWindow = 10 * time.Minute
+
+	// Offenses in a row before a user is muted automatically
+	autoMuteThreshold = 3
+
+	// Length of the first automatic mute. Doubled for every offense after.
+	autoMuteBase = time.Minute
+	autoMuteMax  = 24 * time.Hour
+)
+
+// Chat rate limit state of a user in a community
+type chatBucket struct {
+	tokens      float64
+	refilled    time.Time
+	lastMessage string
+	lastSent    time.Time
+	offenses    int
+	lastOffense time.Time
+}
+
+// AllowChat takes a token from the chat bucket of a user. A message is refused
+// if the bucket is empty or if it repeats the previous message. Returns false
+// along with the amount of recent offenses if the message is refused. The
+// limit is the bucket size and the period is the amount of seconds it takes to
+// refill completely.
+func (c *Community) AllowChat(id bson.ObjectId, message string, limit, period int) (bool, int) {
+	c.Lock()
+	defer c.Unlock()
+
+	if limit <= 0 {
+		limit = defaultChatLimit
+	}
+	if period <= 0 {
+		period = defaultChatLimitPeriod
+	}
+
+	now := time.Now()
+	c.pruneChatBuckets(now, time.Duration(period)*time.Second)
+	b, ok := c.chatBuckets[id]
+	if !ok {
+		b = &chatBucket{tokens: float64(limit), refilled: now}
+		c.chatBuckets[id] = b
+	}
+
+	rate := float64(limit) / float64(period)
+	b.tokens += now.Sub(b.refilled).Seconds() * rate
+	if b.tokens > float64(limit) {
+		b.tokens = float64(limit)
+	}
+	b.refilled = now
+
+	if now.Sub(b.lastOffense) > offenseWindow {
+		b.offenses = 0
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(message))
+	duplicate := normalized == b.lastMessage && now.Sub(b.lastSent) < duplicateWindow
+
+	if b.tokens < 1 || duplicate {
+		b.offenses++
+		b.lastOffense = now
+		debug.Log("User %s was rate limited in community %s. Offense %d", id, c.Id, b.offenses)
+		return false, b.offenses
+	}
+
+	b.tokens--
+	b.lastMessage = normalized
+	b.lastSent = now
+	return true, b.offenses
+}
+
+// Drops the buckets of users who haven't chatted for long enough that their
+// bucket is full and their offenses are forgotten, since a new bucket would be
+// the same. Runs at most once per offenseWindow. Expects the community to be
+// locked.
+func (c *Community) pruneChatBuckets(now time.Time, period time.Duration) {
+	if now.Sub(c.chatPruned) < offenseWindow {
+		return
+	}
+	c.chatPruned = now
+
+	idle := offenseWindow
+	if period > idle {
+		idle = period
+	}
+
+	for id, b := range c.chatBuckets {
+		if now.Sub(b.refilled) > idle {
+			delete(c.chatBuckets, id)
+		}
+	}
+}
+
+// ChatMuteDuration returns how long a user should be muted for after an amount
+// of offenses in a row. Returns 0 if they shouldn't be muted yet.
+func ChatMuteDuration(offenses int) time.Duration {
+	if offenses < autoMuteThreshold {
+		return 0
+	}
+
+	duration := autoMuteBase
+	for i := autoMuteThreshold; i < offenses; i++ {
+		duration *= 2
+		if duration >= autoMuteMax {
+			return autoMuteMax
+		}
+	}
+	return duration
+}
@@ -6,6 +6,7 @@ import (
 	"hybris/db/dbcommunitystaff"
 	"hybris/db/dbmedia"
 	"hybris/db/dbplaylist"
+	"hybris/db/dbplaylistitem"
 	"hybris/db/dbuser"
 	"hybris/db/dbuserhistory"
 	"hybris/debug"
@@ -19,7 +20,12 @@ import (
 	uppdb "upper.io/db"
 )
 
-var Communities = map[bson.ObjectId]*Community{}
+var (
+	communities = map[bson.ObjectId]*Community{}
+
+	// Guards communities, which is reachable from HTTP handlers
+	communitiesM sync.RWMutex
+)
 
 type Community struct {
 	sync.Mutex
@@ -28,198 +34,373 @@ type Community struct {
 	Population []bson.ObjectId
 	Waitlist   []bson.ObjectId
 	Timer      *time.Timer
+
+	// Set after repeated failures to advance. A quarantined community doesn't
+	// start playing when users join the waitlist and retries on its own.
+	Quarantined bool
+
+	// Failed advances in a row
+	failures int
+
+	// Set from a restore until pruneWaitlist runs, while waitlisted users may
+	// still be reconnecting. Nothing starts playing in that time.
+	restoring bool
+
+	// Sequence number of the last advance or waitlist.update event. Lets
+	// clients spot updates they missed.
+	seq int
+
+	// Chat rate limit state of users
+	chatBuckets map[bson.ObjectId]*chatBucket
+
+	// When idle chat buckets were last dropped
+	chatPruned time.Time
+
+	// Listening time of users that hasn't been rewarded yet
+	listeners map[bson.ObjectId]*listener
 }
 
 func NewCommunity(id bson.ObjectId) *Community {
 	debug.Log("Creating new realtime community %s", id)
-	if c, ok := Communities[id]; ok {
+	communitiesM.Lock()
+	defer communitiesM.Unlock()
+
+	if c, ok := communities[id]; ok {
 		debug.Log("Realtime community %s already exists", id)
 		return c
 	}
 	c := &Community{
-		Id:         id,
-		Population: []bson.ObjectId{},
-		Waitlist:   []bson.ObjectId{},
-		Timer:      time.NewTimer(0),
+		Id:          id,
+		Population:  []bson.ObjectId{},
+		Waitlist:    []bson.ObjectId{},
+		Timer:       time.NewTimer(0),
+		chatBuckets: map[bson.ObjectId]*chatBucket{},
+		listeners:   map[bson.ObjectId]*listener{},
 	}
 	debug.Log("Created new realtime community %s", id)
-	Communities[id] = c
+	communities[id] = c
 	return c
 }
 
+// GetCommunity returns the realtime community with the id, if it exists
+func GetCommunity(id bson.ObjectId) (*Community, bool) {
+	communitiesM.RLock()
+	defer communitiesM.RUnlock()
+
+	c, ok := communities[id]
+	return c, ok
+}
+
+// CommunityList returns every realtime community. The list is a copy, so it's
+// safe to use while communities are being created.
+func CommunityList() []*Community {
+	communitiesM.RLock()
+	defer communitiesM.RUnlock()
+
+	list := make([]*Community, 0, len(communities))
+	for _, c := range communities {
+		list = append(list, c)
+	}
+	return list
+}
+
 func (c *Community) Advance() {
-	debug.Log("Advancing community %s", c.Id)
 	c.Lock()
 	defer c.Unlock()
+	c.advance(true, "")
+}
+
+// Expects the community to be locked. If recycle is false the current DJ
+// will not be put back into the waitlist regardless of DJ recycling.
+// The moderatorId is recorded in the history entry when a moderator ended the
+// play early and is empty otherwise.
+func (c *Community) advance(recycle bool, moderatorId bson.ObjectId) {
+	debug.Log("Advancing community %s", c.Id)
 
 	_ = c.Timer.Stop()
 
+	// Failed lookups aren't retried in place since that would hold the lock
+	// during the backoff. fail reschedules the whole advance instead.
 	communityData, err := dbcommunity.GetId(c.Id)
 	if err != nil {
-		debug.Log("Failed to retrieve community data during advance. Panicking: %s", err.Error())
-		c.Panic()
+		debug.Log("Failed to retrieve community data during advance: %s", err.Error())
+		c.fail()
 		return
 	}
 
+	var previous *structs.VoteCount
 	if c.Media != nil {
-		media, err := dbmedia.LockGet(c.Media.Media.Id)
-		if err != nil {
-			debug.Log("Failed to retrieve media during advance. Panicking: %s", err.Error())
-			dbmedia.Unlock(c.Media.Media.Id)
-			c.Panic()
-			return
+		previous = &structs.VoteCount{
+			Woot: len(c.Media.Votes.Woot),
+			Meh:  len(c.Media.Votes.Meh),
+			Save: len(c.Media.Votes.Save),
 		}
 
-		media.Woots += len(c.Media.Votes.Woot)
-		media.Mehs += len(c.Media.Votes.Meh)
-		media.Saves += len(c.Media.Votes.Save)
+		// Losing the history of a play shouldn't stop the next one
+		go c.record(*c.Media, moderatorId)
 
-		if err := media.Save(); err != nil {
-			debug.Log("Failed to save media during advance. Panicking: %s", err.Error())
-			dbmedia.Unlock(media.Id)
-			c.Panic()
-			return
+		c.rewardDj()
+
+		if recycle && communityData.DjRecycling && c.inPopulation(c.Media.DjId) {
+			c.Waitlist = append(c.Waitlist, c.Media.DjId)
 		}
+	}
 
-		dbmedia.Unlock(media.Id)
+	c.Media = nil
 
-		ch, err := dbcommunityhistory.New(c.Id, c.Media.DjId, c.Media.Media.Id)
-		if err != nil {
-			debug.Log("Failed to create community history during advance. Panicking: %s", err.Error())
-			c.Panic()
+	for len(c.Waitlist) > 0 {
+		userId := c.Waitlist[0]
+
+		playing, err := c.nextMedia(userId)
+		if err == errBrokenPlaylist {
+			debug.Log("User %s has nothing playable in their playlist. Skipping", userId)
+			c.Waitlist = c.Waitlist[1:]
+			c.emitError("playlist", userId)
+			continue
+		} else if err != nil {
+			debug.Log("Failed to retrieve media of user %s during advance: %s", userId, err.Error())
+			c.emitAdvance(previous)
+			c.fail()
 			return
 		}
 
-		if err := ch.Save(); err != nil {
-			debug.Log("Failed to create community history during advance. Panicking: %s", err.Error())
-			c.Panic()
-			return
+		c.Media = playing
+		c.Waitlist = c.Waitlist[1:]
+		go notifyFollowers(userId, "friend.dj", message.S{"communityId": c.Id})
+		c.scheduleAdvance(time.Duration(playing.Media.Length) * time.Second)
+		break
+	}
 
-		}
+	c.failures = 0
+	c.Quarantined = false
+	c.emitAdvance(previous)
+	c.snapshot()
 
-		uh, err := dbuserhistory.New(c.Id, c.Media.DjId, c.Media.Media.Id)
-		if err != nil {
-			debug.Log("Failed to create user history during advance. Panicking: %s", err.Error())
-			c.Panic()
-			return
-		}
+	debug.Log("Finished advancing community %s", c.Id)
+}
 
-		if err := uh.Save(); err != nil {
-			debug.Log("Failed to save user history during advance. Panicking: %s", err.Error())
-			c.Panic()
-			return
+// Tells the community what is playing now along with the final vote counts of
+// the previous media, if any. Changes to the waitlist made by the advance are
+// carried by this event instead of a waitlist.update. Expects the community to
+// be locked.
+func (c *Community) emitAdvance(previous *structs.VoteCount) {
+	var playing *structs.CommunityPlayingInfo
+	if c.Media != nil {
+		p := *c.Media
+		playing = &p
+	}
 
-		}
+	c.seq++
+	c.Emit(message.NewEvent("advance", message.S{
+		"seq":      c.seq,
+		"previous": previous,
+		"playing":  playing,
+		"waitlist": c.copyWaitlist(),
+	}))
+}
 
-		if communityData.DjRecycling {
-			c.Waitlist = append(c.Waitlist, c.Media.DjId)
-		}
+// Records a play that just ended, retrying on failure. Runs without the
+// community lock so the retries don't stall the community.
+func (c *Community) record(played structs.CommunityPlayingInfo, moderatorId bson.ObjectId) {
+	if err := c.recordPlay(played, moderatorId); err != nil {
+		debug.Log("Failed to record play in community %s: %s", c.Id, err.Error())
+		c.Lock()
+		c.emitError("history", "")
+		c.Unlock()
 	}
+}
 
-	c.Media = nil
+// Records a play in the media stats and the community and user histories
+func (c *Community) recordPlay(played structs.CommunityPlayingInfo, moderatorId bson.ObjectId) error {
+	woots := len(played.Votes.Woot)
+	mehs := len(played.Votes.Meh)
+	saves := len(played.Votes.Save)
 
-	if len(c.Waitlist) > 0 {
-		userId := c.Waitlist[0]
+	media, err := dbmedia.LockGet(played.Media.Id)
+	defer dbmedia.Unlock(played.Media.Id)
+	if err != nil {
+		return err
+	}
 
-		user, ok := Users[userId]
-		if !ok {
-			debug.Log("User %s does not exist. Cannot confirm community state. Panicking", userId)
-			c.Panic()
-			return
-		}
+	media.Woots += woots
+	media.Mehs += mehs
+	media.Saves += saves
 
-		user.Lock()
-		defer user.Unlock()
+	if err := retry(media.Save); err != nil {
+		return err
+	}
 
-		playlist, err := dbplaylist.Get(uppdb.Cond{
-			"ownerId":  userId,
-			"selected": true,
-		})
+	ch, err := dbcommunityhistory.New(c.Id, played.DjId, played.Media.Id)
+	if err != nil {
+		return err
+	}
 
-		if err != nil {
-			debug.Log("Failed to retrieve playlist %s during advance. Panicking: %s", err.Error())
-			c.Panic()
-			return
-		}
+	ch.Title = played.Media.Title
+	ch.Artist = played.Media.Artist
+	ch.Woots = woots
+	ch.Mehs = mehs
+	ch.Saves = saves
+	ch.ModeratorId = moderatorId
 
-		items, err := playlist.GetItems()
+	if err := retry(ch.Save); err != nil {
+		return err
+	}
 
-		if err != nil {
-			debug.Log("Failed to retrieve playlist items for %s during advance. Panicking: %s", err.Error())
-			c.Panic()
-			return
-		}
+	// The DJ is locked while their play is saved so their running totals
+	// stay in step with their history
+	dj, err := dbuser.LockGet(played.DjId)
+	defer dbuser.Unlock(played.DjId)
+	if err != nil {
+		return err
+	}
 
-		playlistItem := items[0]
+	uh, err := dbuserhistory.New(c.Id, played.DjId, played.Media.Id)
+	if err != nil {
+		return err
+	}
 
-		items = append(items[1:], playlistItem)
+	uh.Title = played.Media.Title
+	uh.Artist = played.Media.Artist
+	uh.Woots = woots
+	uh.Mehs = mehs
+	uh.Saves = saves
 
-		if err := playlist.SaveItems(items); err != nil {
-			debug.Log("Failed to save playlist items during advance. Panicking: %s", err.Error())
-			c.Panic()
-			return
-		}
+	if err := retry(uh.Save); err != nil {
+		return err
+	}
 
-		media, err := dbmedia.GetId(playlistItem.MediaId)
+	// Totals that were never counted will include this play when they are
+	if dj.Totals == nil {
+		return nil
+	}
 
-		if err != nil {
-			debug.Log("Failed to retrieve media %s during advance. Panicking: %s", playlistItem.MediaId, err.Error())
-			c.Panic()
-			return
+	dj.Totals.Plays++
+	dj.Totals.Woots += woots
+	dj.Totals.Mehs += mehs
+	return retry(dj.Save)
+}
+
+// Picks the next playable item from the selected playlist of a DJ and rotates
+// it to the end. Items whose media no longer exists are skipped. Returns
+// errBrokenPlaylist if the DJ has nothing to play. Expects the community to be
+// locked.
+func (c *Community) nextMedia(userId bson.ObjectId) (*structs.CommunityPlayingInfo, error) {
+	// Waitlisted users may be offline after a restore
+	if user, ok := GetUser(userId); ok {
+		user.Lock()
+		defer user.Unlock()
+	}
+
+	playlist, err := dbplaylist.Get(uppdb.Cond{
+		"ownerId":  userId,
+		"selected": true,
+	})
+	if err == uppdb.ErrNoMoreRows {
+		return nil, errBrokenPlaylist
+	} else if err != nil {
+		return nil, err
+	}
+
+	items, err := playlist.GetItems()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		media, err := dbmedia.GetId(item.MediaId)
+		if err == uppdb.ErrNoMoreRows {
+			debug.Log("Media %s of playlist %s no longer exists. Skipping", item.MediaId, playlist.Id)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// Skipped items are rotated along with the one that plays
+		rotated := append(append([]dbplaylistitem.PlaylistItem{}, items[i+1:]...), items[:i+1]...)
+		if err := playlist.SaveItems(rotated); err != nil {
+			return nil, err
 		}
 
-		c.Media = &structs.CommunityPlayingInfo{
-			DjId:    c.Waitlist[0],
+		return &structs.CommunityPlayingInfo{
+			DjId:    userId,
 			Started: time.Now(),
-			Media:   structs.ResolvedMediaInfo{media.Struct(), playlistItem.Artist, playlistItem.Title},
+			Media:   structs.ResolvedMediaInfo{media.Struct(), item.Artist, item.Title},
 			Votes: structs.Votes{
 				[]bson.ObjectId{},
 				[]bson.ObjectId{},
 				[]bson.ObjectId{},
 			},
-		}
-
-		c.Waitlist = c.Waitlist[1:]
-		c.Timer = time.AfterFunc(time.Duration(media.Length)*time.Second, c.Advance)
+		}, nil
 	}
 
-	debug.Log("Finished advancing community %s", c.Id)
-	// go c.Emit(message.NewEvent("waitlist.update", c.Waitlist))
-	// go c.Emit(message.NewEvent("advance", c.Waitlist))
-	// Make this just one update
+	return nil, errBrokenPlaylist
 }
 
-func (c *Community) Panic() {
-	debug.Log("Community %s is panicking", c.Id)
-	for _, v := range c.Population {
-		u, ok := Users[v]
-		if ok {
-			u.Panic()
-		}
+// Handles an advance that couldn't be completed. The advance is retried later
+// and the community is quarantined after repeated failures. Users stay
+// connected either way. Expects the community to be locked.
+func (c *Community) fail() {
+	c.failures++
+
+	if c.failures >= maxFailures {
+		debug.Log("Community %s failed to advance %d times in a row. Quarantining", c.Id, c.failures)
+		c.Quarantined = true
+		c.emitError("quarantine", "")
+		c.scheduleAdvance(quarantineDuration)
+	} else {
+		c.emitError("advance", "")
+		c.scheduleAdvance(time.Duration(c.failures) * failureDelay)
 	}
 
-	debug.Log("Community %s destroyed", c.Id)
+	c.snapshot()
+}
 
-	c.Timer.Stop()
-	c = nil
+// Tells the community that something went wrong. The userId is set when the
+// error concerns a single user.
+func (c *Community) emitError(reason string, userId bson.ObjectId) {
+	data := message.S{"reason": reason}
+	if userId != "" {
+		data["userId"] = userId
+	}
+	c.Emit(message.NewEvent("community.error", data))
+}
 
-	// Emit some sort of emergency event
-	// Also, think about a new way of handling fatal errors. This
-	// doesn't seem like the best way to do it since it DESTROYS the community.
+// Schedules an advance for the media that is currently playing. If the media
+// changes before the timer fires, the scheduled advance does nothing.
+func (c *Community) scheduleAdvance(d time.Duration) {
+	playing := c.Media
+	c.Timer = time.AfterFunc(d, func() {
+		c.Lock()
+		defer c.Unlock()
+		if c.Media != playing {
+			debug.Log("Scheduled advance for community %s is stale. Ignoring", c.Id)
+			return
+		}
+		c.advance(true, "")
+	})
 }
 
-func (c Community) GetState() structs.CommunityState {
+// GetState returns a copy of the booth that is safe to use after the lock is
+// released. Expects the community to be locked.
+func (c *Community) GetState() structs.CommunityState {
+	var playing *structs.CommunityPlayingInfo
+	if c.Media != nil {
+		p := *c.Media
+		playing = &p
+	}
+
 	return structs.CommunityState{
-		Waitlist:   c.Waitlist,
-		NowPlaying: c.Media,
+		Waitlist:   c.copyWaitlist(),
+		NowPlaying: playing,
+		Seq:        c.seq,
 	}
 }
 
 func (c *Community) Emit(e message.Message) {
 	population := c.Population
 	for _, p := range population {
-		if u, ok := Users[p]; ok {
-			go e.Dispatch(u.Client)
+		if u, ok := GetUser(p); ok {
+			u.Send(e)
 		} else {
 			debug.Log("User %s in community %s population doesn't exist. Should panic",
 				p, c.Id)
@@ -230,17 +411,38 @@ func (c *Community) Emit(e message.Message) {
 func (c *Community) Join(id bson.ObjectId) {
 	debug.Log("Adding user %s to community %s population", id, c.Id)
 	c.Lock()
-	defer c.Unlock()
-
 	for _, p := range c.Population {
 		if p == id {
+			c.Unlock()
 			debug.Log("User %s is already in community %s", id, c.Id)
 			return
 		}
 	}
 
 	c.Population = append(c.Population, id)
+	c.listeners[id] = &listener{counted: time.Now()}
+	c.Unlock()
 	debug.Log("Successfully added user %s to community %s population", id, c.Id)
+	go notifyFollowers(id, "friend.join", message.S{"communityId": c.Id})
+
+	// The lookups run without the lock so the rest of the community doesn't
+	// wait on them
+	user, err := dbuser.GetId(id)
+	if err != nil {
+		debug.Log("Failed to retrieve user %s to announce join: %s", id, err.Error())
+		return
+	}
+
+	role, _ := c.GetRole(id)
+	info := communityUserInfo(user.Struct(), role)
+
+	c.Lock()
+	defer c.Unlock()
+
+	// The user may have left during the lookups
+	if c.inPopulation(id) {
+		c.Emit(message.NewEvent("user.join", info))
+	}
 }
 
 func (c *Community) Leave(id bson.ObjectId) {
@@ -251,14 +453,250 @@ func (c *Community) Leave(id bson.ObjectId) {
 	for i, p := range c.Population {
 		if p == id {
 			c.Population = append(c.Population[:i], c.Population[i+1:]...)
+			delete(c.listeners, id)
 			debug.Log("Successfully removed user %s from community %s population",
 				id, c.Id)
+			c.removeFromWaitlist(id)
+			c.Emit(message.NewEvent("user.leave", message.S{"id": id}))
+			return
 		}
 	}
 	debug.Log("Could not remove user %s from community %s population. Isn't in community",
 		id, c.Id)
 }
 
+// JoinWaitlist adds a user to the end of the waitlist and starts playing if
+// the booth is empty. Returns false if the user is already DJing or waiting.
+func (c *Community) JoinWaitlist(id bson.ObjectId) bool {
+	debug.Log("Adding user %s to community %s waitlist", id, c.Id)
+	c.Lock()
+	defer c.Unlock()
+
+	if c.IsDj(id) || c.WaitlistPosition(id) >= 0 {
+		debug.Log("User %s is already in community %s booth", id, c.Id)
+		return false
+	}
+
+	c.Waitlist = append(c.Waitlist, id)
+	c.waitlistChanged()
+
+	if c.Media == nil && !c.Quarantined && !c.restoring {
+		c.advance(true, "")
+	}
+
+	debug.Log("Successfully added user %s to community %s waitlist", id, c.Id)
+	return true
+}
+
+// LeaveWaitlist removes a user from the waitlist. If the user is currently
+// DJing, their media is skipped and they aren't recycled into the waitlist.
+// Returns false if the user wasn't in the booth.
+func (c *Community) LeaveWaitlist(id bson.ObjectId) bool {
+	debug.Log("Removing user %s from community %s booth", id, c.Id)
+	c.Lock()
+	defer c.Unlock()
+
+	if c.IsDj(id) {
+		c.advance(false, "")
+		return true
+	}
+
+	return c.removeFromWaitlist(id)
+}
+
+// Skip skips the media of the current DJ. Returns false if the user isn't the
+// current DJ.
+func (c *Community) Skip(id bson.ObjectId) bool {
+	debug.Log("User %s is skipping in community %s", id, c.Id)
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.IsDj(id) {
+		debug.Log("User %s is not the DJ in community %s. Cannot skip", id, c.Id)
+		return false
+	}
+
+	c.advance(true, "")
+	return true
+}
+
+// ForceSkip skips the media of the current DJ on behalf of a moderator.
+// Returns false if nothing is playing.
+func (c *Community) ForceSkip(moderatorId bson.ObjectId) bool {
+	debug.Log("Moderator %s is force skipping in community %s", moderatorId, c.Id)
+	c.Lock()
+	defer c.Unlock()
+
+	if c.Media == nil {
+		debug.Log("Nothing is playing in community %s. Cannot force skip", c.Id)
+		return false
+	}
+
+	c.advance(true, moderatorId)
+	return true
+}
+
+// AddDj adds a user in the community to the end of the waitlist. Returns false
+// if the user isn't in the community or is already in the booth.
+func (c *Community) AddDj(id bson.ObjectId) bool {
+	debug.Log("Adding user %s to community %s booth", id, c.Id)
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.inPopulation(id) || c.IsDj(id) || c.WaitlistPosition(id) >= 0 {
+		debug.Log("User %s cannot be added to community %s booth", id, c.Id)
+		return false
+	}
+
+	c.Waitlist = append(c.Waitlist, id)
+	c.waitlistChanged()
+
+	if c.Media == nil && !c.Quarantined && !c.restoring {
+		c.advance(true, "")
+	}
+
+	return true
+}
+
+// RemoveDj removes a user from the booth on behalf of a moderator. If the user
+// is currently DJing, their media is skipped and they aren't recycled. Returns
+// false if the user wasn't in the booth.
+func (c *Community) RemoveDj(id, moderatorId bson.ObjectId) bool {
+	debug.Log("Moderator %s is removing user %s from community %s booth", moderatorId, id, c.Id)
+	c.Lock()
+	defer c.Unlock()
+
+	if c.IsDj(id) {
+		c.advance(false, moderatorId)
+		return true
+	}
+
+	return c.removeFromWaitlist(id)
+}
+
+// MoveDj moves a user to a position in the waitlist. The position is clamped
+// to the waitlist bounds. Returns false if the user isn't in the waitlist.
+func (c *Community) MoveDj(id bson.ObjectId, position int) bool {
+	debug.Log("Moving user %s to position %d in community %s waitlist", id, position, c.Id)
+	c.Lock()
+	defer c.Unlock()
+
+	current := c.WaitlistPosition(id)
+	if current < 0 {
+		debug.Log("User %s is not in community %s waitlist. Cannot move", id, c.Id)
+		return false
+	}
+
+	waitlist := append([]bson.ObjectId{}, c.Waitlist[:current]...)
+	waitlist = append(waitlist, c.Waitlist[current+1:]...)
+
+	if position < 0 {
+		position = 0
+	} else if position > len(waitlist) {
+		position = len(waitlist)
+	}
+
+	waitlist = append(waitlist[:position], append([]bson.ObjectId{id}, waitlist[position:]...)...)
+	c.Waitlist = waitlist
+	c.waitlistChanged()
+
+	return true
+}
+
+// IsDj checks whether the user is currently DJing. Expects the community to
+// be locked.
+func (c *Community) IsDj(id bson.ObjectId) bool {
+	return c.Media != nil && c.Media.DjId == id
+}
+
+// WaitlistPosition returns the zero based position of the user in the
+// waitlist, or -1 if they aren't in it. Expects the community to be locked.
+func (c *Community) WaitlistPosition(id bson.ObjectId) int {
+	for i, w := range c.Waitlist {
+		if w == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Expects the community to be locked
+func (c *Community) removeFromWaitlist(id bson.ObjectId) bool {
+	i := c.WaitlistPosition(id)
+	if i < 0 {
+		debug.Log("User %s is not in community %s waitlist", id, c.Id)
+		return false
+	}
+
+	c.Waitlist = append(c.Waitlist[:i], c.Waitlist[i+1:]...)
+	c.waitlistChanged()
+	debug.Log("Successfully removed user %s from community %s waitlist", id, c.Id)
+	return true
+}
+
+// Expects the community to be locked
+func (c *Community) inPopulation(id bson.ObjectId) bool {
+	for _, p := range c.Population {
+		if p == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Notifies the community that the waitlist has changed. Expects the community
+// to be locked.
+func (c *Community) waitlistChanged() {
+	c.seq++
+	c.Emit(message.NewEvent("waitlist.update", message.S{
+		"seq":      c.seq,
+		"waitlist": c.copyWaitlist(),
+	}))
+	c.snapshot()
+}
+
+// Expects the community to be locked
+func (c *Community) copyWaitlist() []bson.ObjectId {
+	waitlist := make([]bson.ObjectId, len(c.Waitlist))
+	copy(waitlist, c.Waitlist)
+	return waitlist
+}
+
+// Evict removes a user from the community population and clears their
+// current community if it's this one. If the user is DJing, their media is
+// skipped on behalf of the moderator.
+func (c *Community) Evict(id, moderatorId bson.ObjectId) {
+	debug.Log("Evicting user %s from community %s", id, c.Id)
+	c.Leave(id)
+	if u, ok := GetUser(id); ok {
+		u.Lock()
+		if u.CommunityId == c.Id {
+			u.CommunityId = ""
+		}
+		u.Unlock()
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.IsDj(id) {
+		c.advance(false, moderatorId)
+	}
+}
+
+// GetRole returns the community role of a user. Users who aren't staff
+// members have the User role.
+func (c *Community) GetRole(userId bson.ObjectId) (int, error) {
+	staff, err := dbcommunitystaff.Get(uppdb.Cond{"communityId": c.Id, "userId": userId})
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ModerationRoles.User, nil
+	} else if err != nil {
+		debug.Log("Could not retrieve role of user %s in community %s: %s", userId, c.Id, err.Error())
+		return enums.ModerationRoles.User, err
+	}
+	return staff.Role, nil
+}
+
 func (c *Community) HasPermission(userId bson.ObjectId, required int) bool {
 	debug.Log("Checking to see if user %s has permission %d in community %s",
 		userId, required, c.Id)
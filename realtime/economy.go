@@ -0,0 +1,105 @@
+package realtime
+
+import (
+	"hybris/debug"
+	"hybris/economy"
+	"hybris/enums"
+	"hybris/socket/message"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// How often listening time is counted. Listeners are rewarded once they have
+// listened for a full listen interval, so this only sets the precision.
+const listenTick = time.Minute
+
+// Listening time of a user in a community that hasn't been rewarded yet
+type listener struct {
+	counted  time.Time
+	listened time.Duration
+}
+
+// RewardListeners starts counting the time users spend listening in every
+// community and rewarding them for it
+func RewardListeners() {
+	go func() {
+		for range time.Tick(listenTick) {
+			for _, c := range CommunityList() {
+				c.rewardListeners()
+			}
+		}
+	}()
+}
+
+// Adds the time since the last count to every listener and rewards the ones
+// who reached a listen interval. Time only counts while something is playing,
+// and not for the DJ, who earns from woots instead, or for users who are away.
+func (c *Community) rewardListeners() {
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	for _, id := range c.Population {
+		l, ok := c.listeners[id]
+		if !ok {
+			c.listeners[id] = &listener{counted: now}
+			continue
+		}
+
+		elapsed := now.Sub(l.counted)
+		l.counted = now
+
+		if c.Media == nil || c.Media.DjId == id {
+			continue
+		}
+		if u, ok := GetUser(id); !ok || u.GetStatus() == enums.UserStatuses.Away {
+			continue
+		}
+
+		var points int
+		points, l.listened = economy.ListenRewards(l.listened + elapsed)
+		if points > 0 {
+			go award(id, c.Id, enums.Currencies.Points, points, enums.LedgerReasons.Listen)
+		}
+	}
+}
+
+// Rewards the DJ of the media that just ended for its woots. Expects the
+// community to be locked.
+func (c *Community) rewardDj() {
+	djId := c.Media.DjId
+	if points := economy.DjPoints(djId, c.Media.Votes.Woot); points > 0 {
+		go award(djId, c.Id, enums.Currencies.Points, points, enums.LedgerReasons.Woot)
+	}
+	if diamonds := economy.DjDiamonds(djId, c.Media.Votes.Woot); diamonds > 0 {
+		go award(djId, c.Id, enums.Currencies.Diamonds, diamonds, enums.LedgerReasons.PopularMedia)
+	}
+}
+
+func award(userId, communityId bson.ObjectId, currency, amount, reason int) {
+	balance, err := economy.Award(userId, communityId, currency, amount, reason)
+	if err != nil {
+		debug.Log("Failed to award %d of currency %d to user %s: %s", amount, currency, userId, err.Error())
+		return
+	}
+
+	u, ok := GetUser(userId)
+	if !ok {
+		return
+	}
+
+	if currency == enums.Currencies.Diamonds {
+		u.Send(message.NewEvent("diamonds.update", message.S{
+			"diamonds": balance,
+			"amount":   amount,
+			"reason":   reason,
+		}))
+	} else {
+		u.Send(message.NewEvent("points.update", message.S{
+			"points": balance,
+			"amount": amount,
+			"reason": reason,
+		}))
+	}
+}
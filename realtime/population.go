@@ -0,0 +1,89 @@
+package realtime
+
+import (
+	"hybris/db/dbcommunitystaff"
+	"hybris/db/dbuser"
+	"hybris/enums"
+	"hybris/structs"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+// PopulationCount returns the amount of users in the community
+func (c *Community) PopulationCount() int {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.Population)
+}
+
+// GetUsers returns up to max users of the community population starting at
+// offset, along with their community role and status.
+func (c *Community) GetUsers(offset, max int) ([]structs.CommunityUserInfo, error) {
+	c.Lock()
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(c.Population) {
+		offset = len(c.Population)
+	}
+	end := offset + max
+	if max < 0 || end > len(c.Population) {
+		end = len(c.Population)
+	}
+	ids := append([]bson.ObjectId{}, c.Population[offset:end]...)
+	c.Unlock()
+
+	payload := []structs.CommunityUserInfo{}
+	if len(ids) <= 0 {
+		return payload, nil
+	}
+
+	users, err := dbuser.GetMulti(-1, uppdb.Cond{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	staff, err := dbcommunitystaff.GetMulti(-1, uppdb.Cond{"communityId": c.Id, "userId": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	roles := map[bson.ObjectId]int{}
+	for _, s := range staff {
+		roles[s.UserId] = s.Role
+	}
+
+	infos := map[bson.ObjectId]structs.UserInfo{}
+	for _, u := range users {
+		infos[u.Id] = u.Struct()
+	}
+
+	// Keep the order of the population
+	for _, id := range ids {
+		info, ok := infos[id]
+		if !ok {
+			continue
+		}
+		role, ok := roles[id]
+		if !ok {
+			role = enums.ModerationRoles.User
+		}
+		payload = append(payload, communityUserInfo(info, role))
+	}
+
+	return payload, nil
+}
+
+func communityUserInfo(info structs.UserInfo, role int) structs.CommunityUserInfo {
+	status := ""
+	if u, ok := GetUser(info.Id); ok {
+		status = u.GetStatus()
+	}
+
+	return structs.CommunityUserInfo{
+		UserInfo: info,
+		Role:     role,
+		Status:   status,
+	}
+}
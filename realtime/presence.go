@@ -0,0 +1,54 @@
+package realtime
+
+import (
+	"hybris/db/dbblock"
+	"hybris/db/dbfollow"
+	"hybris/debug"
+	"hybris/socket/message"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Tells every online follower of a user about something the user did. Runs
+// a query, so call it in its own goroutine when holding locks.
+func notifyFollowers(id bson.ObjectId, name string, data message.S) {
+	followers, err := dbfollow.Followers(id)
+	if err != nil {
+		debug.Log("Failed to retrieve followers of user %s: %s", id, err.Error())
+		return
+	}
+
+	// Follows are removed on block, but older ones may remain
+	blocked, err := dbblock.BlockedWith(id)
+	if err != nil {
+		debug.Log("Failed to retrieve blocks of user %s: %s", id, err.Error())
+		return
+	}
+
+	data["id"] = id
+	e := message.NewEvent(name, data)
+	for _, f := range followers {
+		if blocked[f] {
+			continue
+		}
+		if u, ok := GetUser(f); ok {
+			u.Send(e)
+		}
+	}
+}
+
+// SetStatus changes the presence status of the user and lets their followers
+// know about it
+func (u *User) SetStatus(status string) {
+	u.Lock()
+	u.Status = status
+	u.Unlock()
+	go notifyFollowers(u.Id, "friend.status", message.S{"status": status})
+}
+
+// GetStatus returns the presence status of the user
+func (u *User) GetStatus() string {
+	u.Lock()
+	defer u.Unlock()
+	return u.Status
+}
@@ -0,0 +1,49 @@
+package realtime
+
+import (
+	"errors"
+	"hybris/debug"
+	"time"
+
+	uppdb "upper.io/db"
+)
+
+const (
+	// Attempts made for a database operation before giving up
+	retryAttempts = 3
+
+	// Delay before the first retry of a database operation. Doubled after
+	// every attempt.
+	retryBackoff = 100 * time.Millisecond
+
+	// Delay before retrying a failed advance. Multiplied by the number of
+	// failures in a row.
+	failureDelay = 5 * time.Second
+
+	// Failed advances in a row before a community is quarantined
+	maxFailures = 3
+
+	// Delay before a quarantined community tries to advance again
+	quarantineDuration = 5 * time.Minute
+)
+
+var errBrokenPlaylist = errors.New("nothing playable in playlist")
+
+// Runs a database operation, retrying it with backoff on transient errors.
+// Missing rows aren't transient and are returned straight away. Sleeps between
+// attempts, so it must not be called while holding a community or user lock.
+func retry(f func() error) (err error) {
+	backoff := retryBackoff
+	for attempt := 1; attempt <= retryAttempts; attempt++ {
+		if err = f(); err == nil || err == uppdb.ErrNoMoreRows {
+			return
+		}
+
+		debug.Log("Attempt %d of %d failed: %s", attempt, retryAttempts, err.Error())
+		if attempt < retryAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return
+}
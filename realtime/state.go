@@ -0,0 +1,107 @@
+package realtime
+
+import (
+	"hybris/db/dbcommunity"
+	"hybris/db/dbcommunitystate"
+	"hybris/debug"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+// How long users who were waitlisted before a restart have to reconnect and
+// rejoin their community before they lose their spot
+const reconnectGracePeriod = 2 * time.Minute
+
+// Persists the waitlist and the media that is playing so the community can be
+// restored after a restart. Expects the community to be locked.
+func (c *Community) snapshot() {
+	state, err := dbcommunitystate.New(c.Id, c.copyWaitlist(), c.Media, c.seq)
+	if err != nil {
+		debug.Log("Failed to create snapshot of community %s: %s", c.Id, err.Error())
+		return
+	}
+
+	if err := state.Save(); err != nil {
+		debug.Log("Failed to save snapshot of community %s: %s", c.Id, err.Error())
+	}
+}
+
+// Restore rehydrates every realtime community from its last snapshot and
+// reschedules the advance of the media that was playing. Communities with a
+// waitlist but nothing playing start once users had time to reconnect. Should
+// be called once on boot before any clients connect.
+func Restore() {
+	states, err := dbcommunitystate.GetMulti(-1, uppdb.Cond{})
+	if err != nil {
+		debug.Log("Failed to retrieve community snapshots: %s", err.Error())
+		return
+	}
+
+	for _, state := range states {
+		if _, err := dbcommunity.GetId(state.Id); err == uppdb.ErrNoMoreRows {
+			debug.Log("Community %s no longer exists. Discarding snapshot", state.Id)
+			if err := state.Delete(); err != nil {
+				debug.Log("Failed to delete snapshot of community %s: %s", state.Id, err.Error())
+			}
+			continue
+		} else if err != nil {
+			debug.Log("Failed to retrieve community %s during restore: %s", state.Id, err.Error())
+			continue
+		}
+
+		c := NewCommunity(state.Id)
+		c.Lock()
+
+		if state.Waitlist != nil {
+			c.Waitlist = state.Waitlist
+		}
+		c.Media = state.Media
+		c.seq = state.Seq
+		c.restoring = true
+
+		if c.Media != nil {
+			length := time.Duration(c.Media.Media.Length) * time.Second
+			remaining := length - time.Since(c.Media.Started)
+			if remaining < 0 {
+				remaining = 0
+			}
+			c.scheduleAdvance(remaining)
+		}
+
+		c.Unlock()
+		time.AfterFunc(reconnectGracePeriod, c.pruneWaitlist)
+		debug.Log("Restored community %s", c.Id)
+	}
+}
+
+// Drops waitlisted users who didn't come back after a restore and skips the
+// DJ if they didn't either. Starts playing if nothing is.
+func (c *Community) pruneWaitlist() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.restoring = false
+
+	waitlist := []bson.ObjectId{}
+	for _, id := range c.Waitlist {
+		if c.inPopulation(id) {
+			waitlist = append(waitlist, id)
+		} else {
+			debug.Log("User %s didn't return to community %s after restore. Removing from waitlist", id, c.Id)
+		}
+	}
+
+	if len(waitlist) != len(c.Waitlist) {
+		c.Waitlist = waitlist
+		c.waitlistChanged()
+	}
+
+	if c.Media != nil && !c.inPopulation(c.Media.DjId) {
+		debug.Log("DJ %s didn't return to community %s after restore. Skipping", c.Media.DjId, c.Id)
+		c.advance(false, "")
+	} else if c.Media == nil && len(c.Waitlist) > 0 && !c.Quarantined {
+		c.advance(true, "")
+	}
+}
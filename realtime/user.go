@@ -2,12 +2,22 @@ package realtime
 
 import (
 	"hybris/debug"
+	"hybris/enums"
+	"hybris/socket/message"
 	"sync"
 
 	"gopkg.in/mgo.v2/bson"
 )
 
-var Users = map[bson.ObjectId]*User{}
+var (
+	users = map[bson.ObjectId]*User{}
+
+	// Guards users, which notifications read from their own goroutines
+	usersM sync.RWMutex
+)
+
+// Messages that can wait to be sent to a user before new ones are dropped
+const outboxSize = 256
 
 type Client interface {
 	Lock()
@@ -23,11 +33,21 @@ type User struct {
 	// Connected   bool
 	Status      string
 	CommunityId bson.ObjectId
+
+	// Guards Client, which deliver reads for every message
+	clientM sync.Mutex
+
+	// Messages waiting to be sent, in order
+	outbox chan message.Message
+	done   chan struct{}
 }
 
 func NewUser(id bson.ObjectId, client Client) *User {
 	debug.Log("Creating new realtime user %s", id)
-	if u, ok := Users[id]; ok {
+	usersM.Lock()
+	defer usersM.Unlock()
+
+	if u, ok := users[id]; ok {
 		debug.Log("Realtime user %s already exists. Hijacking", id)
 		return u.Hijack(client)
 	}
@@ -36,39 +56,121 @@ func NewUser(id bson.ObjectId, client Client) *User {
 		Id:     id,
 		Client: client,
 		// Connected: true,
-		// Still needs to be implemented
-		Status:      "",
+		Status:      enums.UserStatuses.Online,
 		CommunityId: "",
+		outbox:      make(chan message.Message, outboxSize),
+		done:        make(chan struct{}),
 	}
+	go u.deliver()
 	debug.Log("Created new realtime user %s", u.Id)
-	Users[id] = u
+	users[id] = u
+	go notifyFollowers(id, "friend.online", message.S{"status": u.Status})
 	return u
 }
 
-func (u User) GetCommunity() *Community {
+// GetUser returns the realtime user with the id, if they're online
+func GetUser(id bson.ObjectId) (*User, bool) {
+	usersM.RLock()
+	defer usersM.RUnlock()
+
+	u, ok := users[id]
+	return u, ok
+}
+
+// UserList returns every realtime user. The list is a copy, so it's safe to
+// use while users connect and disconnect.
+func UserList() []*User {
+	usersM.RLock()
+	defer usersM.RUnlock()
+
+	list := make([]*User, 0, len(users))
+	for _, u := range users {
+		list = append(list, u)
+	}
+	return list
+}
+
+func (u *User) GetCommunity() *Community {
 	debug.Log("Retrieving current community for realtime user %s", u.Id)
-	return Communities[u.CommunityId]
+	c, _ := GetCommunity(u.GetCommunityId())
+	return c
+}
+
+// GetCommunityId returns the id of the community the user is in, if any
+func (u *User) GetCommunityId() bson.ObjectId {
+	u.Lock()
+	defer u.Unlock()
+	return u.CommunityId
+}
+
+// SetCommunityId changes the community the user is in
+func (u *User) SetCommunityId(id bson.ObjectId) {
+	u.Lock()
+	defer u.Unlock()
+	u.CommunityId = id
 }
 
 func (u *User) Panic() {
 	debug.Log("Realtime user %s panicking", u.Id)
-	u.Client.Terminate()
+	u.GetClient().Terminate()
 	u.Destroy()
 }
 
 func (u *User) Destroy() {
 	debug.Log("Destroying realtime user %s", u.Id)
+	usersM.Lock()
+	if users[u.Id] != u {
+		usersM.Unlock()
+		debug.Log("Realtime user %s is already destroyed", u.Id)
+		return
+	}
+	delete(users, u.Id)
+	usersM.Unlock()
+
 	if community := u.GetCommunity(); community != nil {
 		community.Leave(u.Id)
 	}
-	delete(Users, u.Id)
-	u = nil
+	close(u.done)
+	go notifyFollowers(u.Id, "friend.offline", message.S{})
 	debug.Log("Destroyed realtime user %s", u.Id)
 }
 
 func (u *User) Hijack(c Client) *User {
 	debug.Log("Hijacking realtime user %s", u.Id)
+	u.clientM.Lock()
+	defer u.clientM.Unlock()
+
 	u.Client.Terminate()
 	u.Client = c
 	return u
 }
+
+// GetClient returns the client the user is currently connected with
+func (u *User) GetClient() Client {
+	u.clientM.Lock()
+	defer u.clientM.Unlock()
+	return u.Client
+}
+
+// Send queues a message for the user. Messages are delivered one at a time in
+// the order they were queued, so sequenced events arrive in order. If the
+// user can't keep up the message is dropped, which clients notice through the
+// sequence numbers.
+func (u *User) Send(m message.Message) {
+	select {
+	case u.outbox <- m:
+	default:
+		debug.Log("Outbox of realtime user %s is full. Dropping message", u.Id)
+	}
+}
+
+func (u *User) deliver() {
+	for {
+		select {
+		case m := <-u.outbox:
+			m.Dispatch(u.GetClient())
+		case <-u.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package realtime
+
+import (
+	"hybris/db/dbmedia"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbplaylistitem"
+	"hybris/debug"
+	"hybris/enums"
+	"hybris/socket/message"
+	"hybris/structs"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Vote casts a vote on the media that is currently playing and tells the
+// community about it. Woots and mehs are mutually exclusive, so casting one
+// retracts the other. Returns the change in vote counts, and false if the vote
+// didn't change anything.
+func (c *Community) Vote(id bson.ObjectId, voteType int) (structs.VoteCount, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.vote(id, voteType)
+}
+
+// SaveVote adds the media that is currently playing to a playlist and casts a
+// save vote on it. Both happen under the same lock so the media that is saved
+// is the one that is voted on. Returns false if nothing is playing or the user
+// already saved it.
+func (c *Community) SaveVote(id bson.ObjectId, playlist dbplaylist.Playlist) (dbplaylistitem.PlaylistItem, bool, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.Media == nil || hasVote(c.Media.Votes.Save, id) {
+		return dbplaylistitem.PlaylistItem{}, false, nil
+	}
+
+	media := c.Media.Media
+	item, err := playlist.AddItem(media.Id, media.Title, media.Artist)
+	if err != nil {
+		return dbplaylistitem.PlaylistItem{}, false, err
+	}
+
+	// The item is already saved, so a wrong count shouldn't fail the vote
+	if err := dbmedia.AddPlaylists(media.Id, 1); err != nil {
+		debug.Log("Failed to update playlist count of media %s: %s", media.Id, err.Error())
+	}
+
+	c.vote(id, enums.VoteTypes.Save)
+	return item, true, nil
+}
+
+// Expects the community to be locked.
+func (c *Community) vote(id bson.ObjectId, voteType int) (structs.VoteCount, bool) {
+	debug.Log("User %s is casting vote %d in community %s", id, voteType, c.Id)
+
+	var delta structs.VoteCount
+
+	if c.Media == nil {
+		debug.Log("Nothing is playing in community %s. Cannot vote", c.Id)
+		return delta, false
+	}
+
+	votes := &c.Media.Votes
+
+	switch voteType {
+	case enums.VoteTypes.Woot:
+		if hasVote(votes.Woot, id) {
+			return delta, false
+		}
+		votes.Woot = append(votes.Woot, id)
+		delta.Woot++
+		if removeVote(&votes.Meh, id) {
+			delta.Meh--
+		}
+	case enums.VoteTypes.Meh:
+		if hasVote(votes.Meh, id) {
+			return delta, false
+		}
+		votes.Meh = append(votes.Meh, id)
+		delta.Meh++
+		if removeVote(&votes.Woot, id) {
+			delta.Woot--
+		}
+	case enums.VoteTypes.Save:
+		if hasVote(votes.Save, id) {
+			return delta, false
+		}
+		votes.Save = append(votes.Save, id)
+		delta.Save++
+	default:
+		debug.Log("Invalid vote type %d", voteType)
+		return delta, false
+	}
+
+	c.snapshot()
+	c.Emit(message.NewEvent("vote.update", message.S{
+		"userId": id,
+		"delta":  delta,
+	}))
+
+	debug.Log("User %s successfully voted %d in community %s", id, voteType, c.Id)
+	return delta, true
+}
+
+// Playing returns a copy of the media that is currently playing, or nil if
+// nothing is playing.
+func (c *Community) Playing() *structs.CommunityPlayingInfo {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.Media == nil {
+		return nil
+	}
+
+	playing := *c.Media
+	return &playing
+}
+
+// HasVoted checks whether the user has cast a vote of the given type on the
+// media that is currently playing.
+func (c *Community) HasVoted(id bson.ObjectId, voteType int) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.Media == nil {
+		return false
+	}
+
+	switch voteType {
+	case enums.VoteTypes.Woot:
+		return hasVote(c.Media.Votes.Woot, id)
+	case enums.VoteTypes.Meh:
+		return hasVote(c.Media.Votes.Meh, id)
+	case enums.VoteTypes.Save:
+		return hasVote(c.Media.Votes.Save, id)
+	}
+	return false
+}
+
+func hasVote(votes []bson.ObjectId, id bson.ObjectId) bool {
+	for _, v := range votes {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func removeVote(votes *[]bson.ObjectId, id bson.ObjectId) bool {
+	for i, v := range *votes {
+		if v == id {
+			*votes = append((*votes)[:i], (*votes)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"fmt"
+	"hybris/enums"
+	"hybris/search"
+	"net/http"
+	"strconv"
+	"time"
+
+	gocache "github.com/pmylund/go-cache"
+)
+
+// Listings are cached briefly so the landing page can't hammer the database
+var communitiesCache = gocache.New(5*time.Second, time.Minute)
+
+func communitiesHandler(res http.ResponseWriter, req *http.Request) {
+	offset := 0
+	if o := req.URL.Query().Get("offset"); o != "" {
+		var err error
+		if offset, err = strconv.Atoi(o); err != nil || offset < 0 {
+			WriteResponse(res, Response{enums.ResponseCodes.BadRequest, "Invalid offset.", nil})
+			return
+		}
+	}
+	nsfw := req.URL.Query().Get("nsfw") == "true"
+
+	key := fmt.Sprintf("%d:%t", offset, nsfw)
+	if listings, found := communitiesCache.Get(key); found {
+		WriteResponse(res, Response{enums.ResponseCodes.Ok, "", listings})
+		return
+	}
+
+	listings, err := search.Community("", offset, true, nsfw)
+	if err != nil {
+		WriteResponse(res, Response{enums.ResponseCodes.ServerError, "Server error.", nil})
+		return
+	}
+
+	communitiesCache.Set(key, listings, gocache.DefaultExpiration)
+	WriteResponse(res, Response{enums.ResponseCodes.Ok, "", listings})
+}
@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"hybris/enums"
+	"hybris/lookup"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Wraps a community lookup in a GET handler for /communities/{id} routes
+func communityRoute(find func(bson.ObjectId) (int, interface{})) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		id := req.URL.Query().Get(":id")
+		if !bson.IsObjectIdHex(id) {
+			WriteResponse(res, Response{enums.ResponseCodes.BadRequest, "Invalid community.", nil})
+			return
+		}
+
+		status, data := find(bson.ObjectIdHex(id))
+		switch status {
+		case enums.ResponseCodes.BadRequest:
+			WriteResponse(res, Response{status, "Invalid community.", nil})
+		case enums.ResponseCodes.ServerError:
+			WriteResponse(res, Response{status, "Server error.", nil})
+		default:
+			WriteResponseETag(res, req, Response{status, "", data})
+		}
+	}
+}
+
+func communityHistoryHandler(res http.ResponseWriter, req *http.Request) {
+	params := req.URL.Query()
+
+	var query lookup.HistoryQuery
+	for param, id := range map[string]*bson.ObjectId{
+		"before":  &query.Before,
+		"djId":    &query.DjId,
+		"mediaId": &query.MediaId,
+	} {
+		if v := params.Get(param); v != "" {
+			if !bson.IsObjectIdHex(v) {
+				WriteResponse(res, Response{enums.ResponseCodes.BadRequest, "Invalid " + param + ".", nil})
+				return
+			}
+			*id = bson.ObjectIdHex(v)
+		}
+	}
+
+	for param, t := range map[string]**time.Time{
+		"from": &query.From,
+		"to":   &query.To,
+	} {
+		if v := params.Get(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				WriteResponse(res, Response{enums.ResponseCodes.BadRequest, "Invalid " + param + ".", nil})
+				return
+			}
+			*t = &parsed
+		}
+	}
+
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			WriteResponse(res, Response{enums.ResponseCodes.BadRequest, "Invalid limit.", nil})
+			return
+		}
+		query.Limit = limit
+	}
+
+	communityRoute(func(id bson.ObjectId) (int, interface{}) {
+		return lookup.CommunityHistory(id, query)
+	})(res, req)
+}
@@ -1,10 +1,14 @@
 package routes
 
 import (
+	"crypto/sha1"
 	"encoding/json"
 	"flag"
+	"fmt"
 	"hybris/enums"
+	"hybris/lookup"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/pat"
@@ -47,6 +51,12 @@ func Attach(router *pat.Router) {
 	router.Get("/taken/username/{username}", takenUsernameHandler)
 	router.Get("/taken/email/{email}", takenEmailHandler)
 	router.Get("/socket", socketHandler)
+	router.Get("/communities/{id}/state", communityRoute(lookup.CommunityState))
+	router.Get("/communities/{id}/history", communityHistoryHandler)
+	router.Get("/communities/{id}/staff", communityRoute(lookup.CommunityStaff))
+	router.Get("/communities/{id}", communityRoute(lookup.CommunityInfo))
+	router.Get("/communities", communitiesHandler)
+	router.Get("/users/{username}", userHandler)
 	router.Get("/", indexHandler)
 }
 
@@ -77,6 +87,30 @@ func WriteResponse(res http.ResponseWriter, response Response) {
 	res.Write(data)
 }
 
+// WriteResponseETag writes the response along with an ETag of its content. If
+// the client already has the same content, only 304 Not Modified is written.
+func WriteResponseETag(res http.ResponseWriter, req *http.Request, response Response) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		res.WriteHeader(500)
+		return
+	}
+
+	etag := fmt.Sprintf(`"%x"`, sha1.Sum(data))
+	res.Header().Set("ETag", etag)
+
+	if response.Status == enums.ResponseCodes.Ok {
+		for _, match := range strings.Split(req.Header.Get("If-None-Match"), ",") {
+			if strings.TrimSpace(match) == etag {
+				res.WriteHeader(304)
+				return
+			}
+		}
+	}
+
+	WriteResponse(res, response)
+}
+
 func SetCookie(res http.ResponseWriter, value string) {
 	http.SetCookie(res, &http.Cookie{
 		Name:     "auth",
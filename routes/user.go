@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"hybris/enums"
+	"hybris/lookup"
+	"net/http"
+)
+
+func userHandler(res http.ResponseWriter, req *http.Request) {
+	status, data := lookup.UserProfile(req.URL.Query().Get(":username"))
+	switch status {
+	case enums.ResponseCodes.BadRequest:
+		WriteResponse(res, Response{status, "Invalid user.", nil})
+	case enums.ResponseCodes.ServerError:
+		WriteResponse(res, Response{status, "Server error.", nil})
+	default:
+		WriteResponseETag(res, req, Response{status, "", data})
+	}
+}
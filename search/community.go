@@ -2,34 +2,267 @@ package search
 
 import (
 	"hybris/db/dbcommunity"
+	"hybris/db/dbuser"
 	"hybris/realtime"
+	"hybris/structs"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
 )
 
-type community struct {
-	dbcommunity.Community
-	Host              string `json:"host"`
-	Population        int    `json:"population"`
-	FormatHost        string `json:"-"`
-	FormatName        string `json:"-"`
-	FormatDescription string `json:"-"`
-}
+// Amount of communities returned per page
+const pageSize = 20
+
+// Most rows read by each database query of a search
+const matchLimit = 200
+
+// Shortest query that is also matched anywhere in a field instead of only as a
+// prefix
+const minContainsLength = 3
+
+// Ranking given to each kind of match. A community gets the best ranking of
+// every field it matches.
+const (
+	rankUrlExact     = 100
+	rankNameExact    = 90
+	rankUrlPrefix    = 60
+	rankNamePrefix   = 50
+	rankNameContains = 40
+	rankUrlContains  = 35
+	rankHost         = 20
+	rankDescription  = 10
+)
 
-type CommunityResult struct {
-	Community Community
-	Ranking   int
+type result struct {
+	community  dbcommunity.Community
+	host       dbuser.User
+	population int
+	ranking    int
 }
 
-func Community(query string, sortByPopulation bool) {
-	var results []CommunityResult
+// Community searches communities by url, name, host username and description.
+// An empty query lists every community with users in it. Results are ranked by
+// how well they match, or by population if sortByPopulation is set. NSFW
+// communities are only included if nsfw is set.
+func Community(query string, offset int, sortByPopulation, nsfw bool) ([]structs.LandingCommunityListing, error) {
+	query = strings.TrimSpace(query)
+
+	var communities []dbcommunity.Community
+	var err error
 	if len(query) <= 0 {
-		for _, c := range realtime.Communities {
-			communityData := c.GetCommunity()
-			results = apend(results, CommunityResult{
-				Community: {
-					communityData,
-				},
-				Ranking: 0,
-			})
+		communities, err = active()
+	} else {
+		communities, err = matching(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := hostsOf(communities)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []result{}
+	for _, c := range communities {
+		if c.Nsfw && !nsfw {
+			continue
+		}
+
+		r := result{
+			community: c,
+			host:      hosts[c.HostId],
+		}
+
+		if rc, ok := realtime.GetCommunity(c.Id); ok {
+			r.population = rc.PopulationCount()
 		}
+
+		if len(query) > 0 {
+			r.ranking = rank(query, r)
+		}
+
+		results = append(results, r)
 	}
+
+	sort.Stable(ranked{results, sortByPopulation})
+
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(results) {
+		offset = len(results)
+	}
+	end := offset + pageSize
+	if end > len(results) {
+		end = len(results)
+	}
+
+	listings := []structs.LandingCommunityListing{}
+	for _, r := range results[offset:end] {
+		listings = append(listings, listing(r))
+	}
+
+	return listings, nil
+}
+
+// Sorts results by ranking with population breaking ties, or the other way
+// around when sorting by population
+type ranked struct {
+	results      []result
+	byPopulation bool
+}
+
+func (r ranked) Len() int      { return len(r.results) }
+func (r ranked) Swap(i, j int) { r.results[i], r.results[j] = r.results[j], r.results[i] }
+func (r ranked) Less(i, j int) bool {
+	a, b := r.results[i], r.results[j]
+	if (r.byPopulation || a.ranking == b.ranking) && a.population != b.population {
+		return a.population > b.population
+	}
+	return a.ranking > b.ranking
+}
+
+// Retrieves every community that has users in it
+func active() ([]dbcommunity.Community, error) {
+	ids := []bson.ObjectId{}
+	for _, c := range realtime.CommunityList() {
+		if c.PopulationCount() > 0 {
+			ids = append(ids, c.Id)
+		}
+	}
+
+	if len(ids) <= 0 {
+		return []dbcommunity.Community{}, nil
+	}
+
+	return dbcommunity.GetMulti(-1, uppdb.Cond{"_id": bson.M{"$in": ids}})
+}
+
+// Retrieves the communities matching the query. Urls, names and host
+// usernames are matched by prefix, which can use indexes. Queries of at least
+// minContainsLength also match anywhere in the url, name and description. Each
+// query is capped at matchLimit rows.
+func matching(query string) ([]dbcommunity.Community, error) {
+	// Usernames and urls are stored in lowercase, so a case sensitive pattern
+	// can use their indexes
+	lower := bson.RegEx{Pattern: "^" + regexp.QuoteMeta(strings.ToLower(query))}
+
+	hosts, err := dbuser.GetMulti(matchLimit, uppdb.Cond{"username": lower})
+	if err != nil {
+		return nil, err
+	}
+
+	hostIds := []bson.ObjectId{}
+	for _, h := range hosts {
+		hostIds = append(hostIds, h.Id)
+	}
+
+	communities, err := dbcommunity.GetMulti(matchLimit, uppdb.Or{
+		uppdb.Cond{"url": lower},
+		uppdb.Cond{"name": bson.RegEx{Pattern: "^" + regexp.QuoteMeta(query), Options: "i"}},
+		uppdb.Cond{"hostId": bson.M{"$in": hostIds}},
+	})
+	if err != nil || utf8.RuneCountInString(query) < minContainsLength {
+		return communities, err
+	}
+
+	pattern := bson.RegEx{Pattern: regexp.QuoteMeta(query), Options: "i"}
+	containing, err := dbcommunity.GetMulti(matchLimit, uppdb.Or{
+		uppdb.Cond{"url": pattern},
+		uppdb.Cond{"name": pattern},
+		uppdb.Cond{"description": pattern},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[bson.ObjectId]bool{}
+	for _, c := range communities {
+		seen[c.Id] = true
+	}
+	for _, c := range containing {
+		if !seen[c.Id] {
+			communities = append(communities, c)
+		}
+	}
+
+	return communities, nil
+}
+
+// Retrieves the hosts of the communities mapped by id
+func hostsOf(communities []dbcommunity.Community) (map[bson.ObjectId]dbuser.User, error) {
+	hosts := map[bson.ObjectId]dbuser.User{}
+
+	ids := []bson.ObjectId{}
+	for _, c := range communities {
+		ids = append(ids, c.HostId)
+	}
+
+	if len(ids) <= 0 {
+		return hosts, nil
+	}
+
+	users, err := dbuser.GetMulti(-1, uppdb.Cond{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range users {
+		hosts[u.Id] = u
+	}
+
+	return hosts, nil
+}
+
+func rank(query string, r result) (ranking int) {
+	query = strings.ToLower(query)
+	url := strings.ToLower(r.community.Url)
+	name := strings.ToLower(r.community.Name)
+
+	best := func(rank int, matches bool) {
+		if matches && rank > ranking {
+			ranking = rank
+		}
+	}
+
+	best(rankUrlExact, url == query)
+	best(rankNameExact, name == query)
+	best(rankUrlPrefix, strings.HasPrefix(url, query))
+	best(rankNamePrefix, strings.HasPrefix(name, query))
+	best(rankNameContains, strings.Contains(name, query))
+	best(rankUrlContains, strings.Contains(url, query))
+	best(rankHost, strings.Contains(strings.ToLower(r.host.Username), query))
+	best(rankDescription, strings.Contains(strings.ToLower(r.community.Description), query))
+
+	return
+}
+
+func listing(r result) structs.LandingCommunityListing {
+	l := structs.LandingCommunityListing{
+		Population: r.population,
+		Info: structs.CommunityFullInfo{
+			CommunityInfo: r.community.Struct(),
+			Host:          r.host.Struct(),
+		},
+	}
+
+	rc, ok := realtime.GetCommunity(r.community.Id)
+	if !ok {
+		return l
+	}
+
+	if playing := rc.Playing(); playing != nil {
+		dj, _ := dbuser.GetId(playing.DjId)
+		l.Playing = &structs.CommunityFullPlayingInfo{
+			CommunityPlayingInfo: *playing,
+			Dj:                   dj.Struct(),
+		}
+	}
+
+	return l
 }
@@ -50,7 +50,7 @@ func New(req *http.Request, conn *websocket.Conn) (*Client, error) {
 		return nil, errors.New("couldn't find session")
 	}
 
-	if globalBan, err := dbglobalban.Get(uppdb.Cond{"banneeId": session.UserId}); err == nil {
+	if globalBan, err := dbglobalban.Get(uppdb.Cond{"baneeId": session.UserId}); err == nil {
 		if globalBan.Until == nil || globalBan.Until.After(time.Now()) {
 			return nil, errors.New("banned")
 		} else if err := globalBan.Delete(); err != nil {
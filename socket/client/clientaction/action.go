@@ -8,51 +8,69 @@ import (
 )
 
 var actions = map[string]func(Client, []byte) (int, interface{}){
-	"adm.broadcast":        AdmBroadcast,
-	"adm.globalBan":        AdmGlobalBan,
-	"adm.maintenance":      AdmMaintenance,
-	"chat.delete":          ChatDelete,
-	"chat.send":            ChatSend,
-	"community.create":     CommunityCreate,
-	"community.edit":       CommunityEdit,
-	"community.getHistory": CommunityGetHistory,
-	"community.getInfo":    CommunityGetInfo,
-	"community.getStaff":   CommunityGetStaff,
-	"community.getState":   CommunityGetState,
-	"community.getUsers":   CommunityGetUsers,
-	"community.join":       CommunityJoin,
-	// "community.search":     CommunitySearch,
-	"community.taken": CommunityTaken,
-	// "dj.join":               DjJoin,
-	// "dj.leave":              DjLeave,
-	// "dj.skip":               DjSkip,
-	// "media.add":             MediaAdd,
-	// "media.import":          MediaImport,
-	// "media.search":          MediaSearch,
-	// "moderation.addDj":      ModerationAddDj,
-	// "moderation.ban":        ModerationBan,
-	// "moderation.clearChat":  ModerationClearChat,
-	// "moderation.deleteChat": ModerationDeleteChat,
-	// "moderation.forceSkip":  ModerationForceSkip,
-	// "moderation.kick":       ModerationKick,
-	// "moderation.moveDj":     ModerationMoveDj,
-	// "moderation.mute":       ModerationMute,
-	// "moderation.removeDj":   ModerationRemoveDj,
-	// "moderation.setRole":    ModerationSetRole,
-	// "playlist.activate":     PlaylistActivate,
-	// "playlist.create":       PlaylistCreate,
-	// "playlist.delete":       PlaylistDelete,
-	// "playlist.edit":         PlaylistEdit,
-	// "playlist.get":          PlaylistGet,
-	// "playlist.getList":      PlaylistGetList,
-	// "playlist.move":         PlaylistMove,
-	// "playlistItem.delete":   PlaylistItemDelete,
-	// "playlistItem.edit":     PlaylistItemEdit,
-	// "playlistItem.move":     PlaylistItemMove,
-	// "vote.woot":             VoteWoot,
-	// "vote.meh":              VoteMeh,
-	// "vote.save":             VoteSave,
-	"whoami": Whoami,
+	"adm.broadcast":          AdmBroadcast,
+	"adm.globalBan":          AdmGlobalBan,
+	"adm.maintenance":        AdmMaintenance,
+	"chat.delete":            ChatDelete,
+	"chat.getHistory":        ChatGetHistory,
+	"chat.send":              ChatSend,
+	"community.create":       CommunityCreate,
+	"community.edit":         CommunityEdit,
+	"community.getHistory":   CommunityGetHistory,
+	"community.getInfo":      CommunityGetInfo,
+	"community.getSettings":  CommunityGetSettings,
+	"community.getStaff":     CommunityGetStaff,
+	"community.getState":     CommunityGetState,
+	"community.getUsers":     CommunityGetUsers,
+	"community.join":         CommunityJoin,
+	"community.search":       CommunitySearch,
+	"community.taken":        CommunityTaken,
+	"community.transferHost": CommunityTransferHost,
+	"dj.join":                DjJoin,
+	"dj.leave":               DjLeave,
+	"dj.skip":                DjSkip,
+	"dm.block":               DmBlock,
+	"dm.getConversations":    DmGetConversations,
+	"dm.getHistory":          DmGetHistory,
+	"dm.read":                DmRead,
+	"dm.send":                DmSend,
+	"dm.unblock":             DmUnblock,
+	"friend.add":             FriendAdd,
+	"friend.list":            FriendList,
+	"friend.remove":          FriendRemove,
+	"leaderboard.get":        LeaderboardGet,
+	"media.add":              MediaAdd,
+	"media.import":           MediaImport,
+	"media.search":           MediaSearch,
+	"moderation.addDj":       ModerationAddDj,
+	"moderation.ban":         ModerationBan,
+	"moderation.clearChat":   ModerationClearChat,
+	"moderation.deleteChat":  ModerationDeleteChat,
+	"moderation.forceSkip":   ModerationForceSkip,
+	"moderation.getBans":     ModerationGetBans,
+	"moderation.kick":        ModerationKick,
+	"moderation.moveDj":      ModerationMoveDj,
+	"moderation.mute":        ModerationMute,
+	"moderation.removeDj":    ModerationRemoveDj,
+	"moderation.setRole":     ModerationSetRole,
+	"moderation.unban":       ModerationUnban,
+	"moderation.unmute":      ModerationUnmute,
+	"playlist.activate":      PlaylistActivate,
+	"playlist.create":        PlaylistCreate,
+	"playlist.delete":        PlaylistDelete,
+	"playlist.edit":          PlaylistEdit,
+	"playlist.get":           PlaylistGet,
+	"playlist.getList":       PlaylistGetList,
+	"playlist.move":          PlaylistMove,
+	"playlistItem.delete":    PlaylistItemDelete,
+	"playlistItem.edit":      PlaylistItemEdit,
+	"playlistItem.move":      PlaylistItemMove,
+	"user.getProfile":        UserGetProfile,
+	"user.setStatus":         UserSetStatus,
+	"vote.woot":              VoteWoot,
+	"vote.meh":               VoteMeh,
+	"vote.save":              VoteSave,
+	"whoami":                 Whoami,
 }
 
 func Execute(client Client, msg []byte) {
@@ -32,12 +32,13 @@ func AdmBroadcast(client Client, msg []byte) (int, interface{}) {
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(len(realtime.Users))
+	users := realtime.UserList()
+	wg.Add(len(users))
 	evt := message.NewEvent("server.broadcast", data)
-	for _, realtimeUser := range realtime.Users {
+	for _, realtimeUser := range users {
 		go func(realtimeUser *realtime.User) {
 			defer wg.Done()
-			evt.Dispatch(realtimeUser.Client)
+			evt.Dispatch(realtimeUser.GetClient())
 		}(realtimeUser)
 	}
 
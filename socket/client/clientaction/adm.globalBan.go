@@ -54,7 +54,7 @@ func AdmGlobalBan(client Client, msg []byte) (int, interface{}) {
 		return enums.ResponseCodes.ServerError, nil
 	}
 
-	if u, ok := realtime.Users[bannee.Id]; ok {
+	if u, ok := realtime.GetUser(bannee.Id); ok {
 		community := u.GetCommunity()
 		u.Panic()
 		if community != nil {
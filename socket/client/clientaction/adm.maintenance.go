@@ -34,8 +34,9 @@ func AdmMaintenance(client Client, msg []byte) (int, interface{}) {
 	if realtime.Maintenance {
 		var wg sync.WaitGroup
 
-		wg.Add(len(realtime.Users))
-		for _, realtimeUser := range realtime.Users {
+		users := realtime.UserList()
+		wg.Add(len(users))
+		for _, realtimeUser := range users {
 			go func(realtimeUser *realtime.User) {
 				defer wg.Done()
 				u, err := dbuser.GetId(realtimeUser.Id)
@@ -44,6 +44,7 @@ func ChatDelete(client Client, msg []byte) (int, interface{}) {
 	}
 
 	chat.Deleted = true
+	chat.DeleterId = client.GetRealtimeUser().Id
 	if err := chat.Save(); err != nil {
 		return enums.ResponseCodes.ServerError, nil
 	}
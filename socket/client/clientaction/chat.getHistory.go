@@ -0,0 +1,62 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbchat"
+	"hybris/enums"
+	"hybris/structs"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func ChatGetHistory(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Before bson.ObjectId `json:"before"`
+		Limit  int           `json:"limit"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if data.Limit <= 0 || data.Limit > 50 {
+		data.Limit = 50
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	chats, err := chatHistory(community.Id, data.Before, data.Limit)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, chats
+}
+
+// Retrieves up to max non-deleted chats of a community older than before,
+// oldest first. The id of the first chat can be used as the next cursor.
+func chatHistory(communityId, before bson.ObjectId, max int) ([]structs.Chat, error) {
+	query := uppdb.Cond{"communityId": communityId, "deleted": false}
+	if before != "" {
+		query["_id"] = bson.M{"$lt": before}
+	}
+
+	chats, err := dbchat.GetRecent(max, query)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make([]structs.Chat, len(chats))
+	for i, c := range chats {
+		payload[len(chats)-1-i] = c.Struct()
+	}
+
+	return payload, nil
+}
@@ -3,14 +3,24 @@ package clientaction
 import (
 	"encoding/json"
 	"hybris/db/dbchat"
+	"hybris/db/dbcommunity"
 	"hybris/db/dbmute"
+	"hybris/db/dbuser"
+	"hybris/debug"
 	"hybris/enums"
+	"hybris/parser"
+	"hybris/realtime"
 	"hybris/socket/message"
+	"strings"
 	"time"
 
+	"gopkg.in/mgo.v2/bson"
 	uppdb "upper.io/db"
 )
 
+// How long users are muted for after using a word filtered with the mute action
+const filterMuteDuration = 5 * time.Minute
+
 func ChatSend(client Client, msg []byte) (int, interface{}) {
 	var data struct {
 		Me      bool   `json:"me"`
@@ -36,16 +46,130 @@ func ChatSend(client Client, msg []byte) (int, interface{}) {
 		return enums.ResponseCodes.ServerError, nil
 	}
 
-	chat, err := dbchat.New(client.GetRealtimeUser().Id, community.Id, data.Me, data.Message)
+	communityData, err := dbcommunity.GetId(community.Id)
 	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// Staff aren't rate limited and may always post links
+	staff := community.HasPermission(client.GetRealtimeUser().Id, enums.ModerationRoles.Bouncer)
+
+	if !staff {
+		ok, offenses := community.AllowChat(client.GetRealtimeUser().Id, data.Message, communityData.ChatLimit, communityData.ChatLimitPeriod)
+		if !ok {
+			if duration := realtime.ChatMuteDuration(offenses); duration > 0 {
+				autoMute(community, client.GetRealtimeUser().Id, duration, "Flooding the chat.")
+			}
+			return enums.ResponseCodes.Forbidden, nil
+		}
+	}
+
+	// Mentions and links past the cut aren't part of the saved message
+	parsed := parser.Chat(dbchat.Truncate(data.Message))
+	if len(strings.TrimSpace(parsed.Text)) <= 0 {
 		return enums.ResponseCodes.BadRequest, nil
 	}
 
+	if !staff && communityData.LinksDisabled && len(parsed.Links) > 0 {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	text, action := parser.Filter(parsed.Text, communityData.ChatFilter)
+	switch action {
+	case enums.FilterActions.Mute:
+		if !staff {
+			autoMute(community, client.GetRealtimeUser().Id, filterMuteDuration, "Using a filtered word.")
+		}
+		return enums.ResponseCodes.Forbidden, nil
+	case enums.FilterActions.Block:
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	chat, err := dbchat.New(client.GetRealtimeUser().Id, community.Id, data.Me || parsed.Me, text)
+	if err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	// Links and mentions that were masked by the filter aren't sent along
+	if text != parsed.Text {
+		filtered := parser.Chat(text)
+		parsed.Mentions = filtered.Mentions
+		parsed.Links = filtered.Links
+	}
+
+	mentioned, err := mentionedUsers(community.Id, client.GetRealtimeUser().Id, parsed.Mentions)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	for _, u := range mentioned {
+		chat.Mentions = append(chat.Mentions, u.Id)
+	}
+	chat.Links = parsed.Links
+
 	if err := chat.Save(); err != nil {
 		return enums.ResponseCodes.ServerError, nil
 	}
 
 	community.Emit(message.NewEvent("chat.receive", chat.Struct()))
 
+	mention := message.NewEvent("chat.mention", chat.Struct())
+	for _, u := range mentioned {
+		u.Send(mention)
+	}
+
 	return enums.ResponseCodes.Ok, nil
 }
+
+// Resolves mentioned usernames to the online users in the community. Users
+// can't mention themselves.
+func mentionedUsers(communityId, senderId bson.ObjectId, usernames []string) ([]*realtime.User, error) {
+	mentioned := []*realtime.User{}
+	if len(usernames) <= 0 {
+		return mentioned, nil
+	}
+
+	users, err := dbuser.GetMulti(-1, uppdb.Cond{"username": bson.M{"$in": usernames}})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.Id == senderId {
+			continue
+		}
+		if u, ok := realtime.GetUser(user.Id); ok && u.GetCommunityId() == communityId {
+			mentioned = append(mentioned, u)
+		}
+	}
+
+	return mentioned, nil
+}
+
+// Mutes a user automatically for breaking the chat rules
+func autoMute(community *realtime.Community, userId bson.ObjectId, duration time.Duration, reason string) {
+	until := time.Now().Add(duration)
+	mute, err := dbmute.New(userId, "", community.Id, reason, &until)
+	if err != nil {
+		debug.Log("Failed to create automatic mute for user %s: %s", userId, err.Error())
+		return
+	}
+
+	// Users only have one mute per community
+	if existing, err := dbmute.Get(uppdb.Cond{"muteeId": userId, "communityId": community.Id}); err == nil {
+		if err := existing.Delete(); err != nil {
+			debug.Log("Failed to replace mute of user %s: %s", userId, err.Error())
+			return
+		}
+	} else if err != uppdb.ErrNoMoreRows {
+		debug.Log("Failed to retrieve mute of user %s: %s", userId, err.Error())
+		return
+	}
+
+	if err := mute.Save(); err != nil {
+		debug.Log("Failed to save automatic mute for user %s: %s", userId, err.Error())
+		return
+	}
+
+	community.Emit(message.NewEvent("moderation.mute", mute.Struct()))
+}
@@ -29,7 +29,7 @@ func CommunityCreate(client Client, msg []byte) (int, interface{}) {
 		return enums.ResponseCodes.ServerError, nil
 	}
 
-	if len(communities) >= 3 {
+	if len(communities) >= dbcommunity.MaxHosted {
 		return enums.ResponseCodes.Forbidden, nil
 	}
 
@@ -5,6 +5,7 @@ import (
 	"hybris/db/dbcommunity"
 	"hybris/enums"
 	"hybris/realtime"
+	"hybris/structs"
 	"hybris/validation"
 
 	"gopkg.in/mgo.v2/bson"
@@ -13,13 +14,17 @@ import (
 
 func CommunityEdit(client Client, msg []byte) (int, interface{}) {
 	var data struct {
-		Id              bson.ObjectId `json:"id"`
-		Name            *string       `json:"name"`
-		Description     *string       `json:"description"`
-		WelcomeMessage  *string       `json:"welcomeMessage"`
-		WaitlistEnabled *bool         `json:"waitlistEnabled"`
-		DjRecycling     *bool         `json:"djRecycling"`
-		Nsfw            *bool         `json:"nsfw"`
+		Id              bson.ObjectId             `json:"id"`
+		Name            *string                   `json:"name"`
+		Description     *string                   `json:"description"`
+		WelcomeMessage  *string                   `json:"welcomeMessage"`
+		WaitlistEnabled *bool                     `json:"waitlistEnabled"`
+		DjRecycling     *bool                     `json:"djRecycling"`
+		Nsfw            *bool                     `json:"nsfw"`
+		ChatLimit       *int                      `json:"chatLimit"`
+		ChatLimitPeriod *int                      `json:"chatLimitPeriod"`
+		ChatFilter      *[]structs.ChatFilterRule `json:"chatFilter"`
+		LinksDisabled   *bool                     `json:"linksDisabled"`
 	}
 
 	if err := json.Unmarshal(msg, &data); err != nil {
@@ -78,10 +83,48 @@ func CommunityEdit(client Client, msg []byte) (int, interface{}) {
 		communityData.Nsfw = *data.Nsfw
 	}
 
+	if data.ChatLimit != nil {
+		chatLimit := *data.ChatLimit
+		if !validation.ChatLimit(chatLimit) {
+			return enums.ResponseCodes.BadRequest, nil
+		}
+		communityData.ChatLimit = chatLimit
+	}
+
+	if data.ChatLimitPeriod != nil {
+		chatLimitPeriod := *data.ChatLimitPeriod
+		if !validation.ChatLimitPeriod(chatLimitPeriod) {
+			return enums.ResponseCodes.BadRequest, nil
+		}
+		communityData.ChatLimitPeriod = chatLimitPeriod
+	}
+
+	if data.ChatFilter != nil {
+		chatFilter := *data.ChatFilter
+		if len(chatFilter) > 100 {
+			return enums.ResponseCodes.BadRequest, nil
+		}
+		for _, rule := range chatFilter {
+			if !validation.ChatFilterRule(rule.Pattern, rule.Regex) {
+				return enums.ResponseCodes.BadRequest, nil
+			}
+			if rule.Action != enums.FilterActions.Block &&
+				rule.Action != enums.FilterActions.Mask &&
+				rule.Action != enums.FilterActions.Mute {
+				return enums.ResponseCodes.BadRequest, nil
+			}
+		}
+		communityData.ChatFilter = chatFilter
+	}
+
+	if data.LinksDisabled != nil {
+		communityData.LinksDisabled = *data.LinksDisabled
+	}
+
 	if err := communityData.Save(); err != nil {
 		return enums.ResponseCodes.ServerError, nil
 	}
 
 	// Dispatch a global event to notify all users in the community that data has been updated
-	return enums.ResponseCodes.Ok, communityData.Struct()
+	return enums.ResponseCodes.Ok, communityData.Settings()
 }
@@ -2,11 +2,10 @@ package clientaction
 
 import (
 	"encoding/json"
-	"hybris/db/dbcommunity"
 	"hybris/enums"
+	"hybris/lookup"
 
 	"gopkg.in/mgo.v2/bson"
-	uppdb "upper.io/db"
 )
 
 func CommunityGetInfo(client Client, msg []byte) (int, interface{}) {
@@ -18,12 +17,5 @@ func CommunityGetInfo(client Client, msg []byte) (int, interface{}) {
 		return enums.ResponseCodes.BadRequest, nil
 	}
 
-	communityData, err := dbcommunity.GetId(data.Id)
-	if err == uppdb.ErrNoMoreRows {
-		return enums.ResponseCodes.BadRequest, nil
-	} else if err != nil {
-		return enums.ResponseCodes.ServerError, nil
-	}
-
-	return enums.ResponseCodes.Ok, communityData.Struct()
+	return lookup.CommunityInfo(data.Id)
 }
@@ -2,12 +2,10 @@ package clientaction
 
 import (
 	"encoding/json"
-	"hybris/db/dbcommunity"
-	"hybris/db/dbcommunitystaff"
 	"hybris/enums"
+	"hybris/lookup"
 
 	"gopkg.in/mgo.v2/bson"
-	uppdb "upper.io/db"
 )
 
 func CommunityGetStaff(client Client, msg []byte) (int, interface{}) {
@@ -19,16 +17,5 @@ func CommunityGetStaff(client Client, msg []byte) (int, interface{}) {
 		return enums.ResponseCodes.BadRequest, nil
 	}
 
-	if _, err := dbcommunity.GetId(data.Id); err == uppdb.ErrNoMoreRows {
-		return enums.ResponseCodes.BadRequest, nil
-	} else if err != nil {
-		return enums.ResponseCodes.ServerError, nil
-	}
-
-	history, err := dbcommunitystaff.GetMulti(50, uppdb.Cond{"communityId": data.Id})
-	if err != nil {
-		return enums.ResponseCodes.ServerError, nil
-	}
-
-	return enums.ResponseCodes.Ok, dbcommunitystaff.StructMulti(history)
+	return lookup.CommunityStaff(data.Id)
 }
@@ -2,12 +2,10 @@ package clientaction
 
 import (
 	"encoding/json"
-	"hybris/db/dbcommunity"
 	"hybris/enums"
-	"hybris/realtime"
+	"hybris/lookup"
 
 	"gopkg.in/mgo.v2/bson"
-	uppdb "upper.io/db"
 )
 
 func CommunityGetState(client Client, msg []byte) (int, interface{}) {
@@ -19,14 +17,5 @@ func CommunityGetState(client Client, msg []byte) (int, interface{}) {
 		return enums.ResponseCodes.BadRequest, nil
 	}
 
-	communityData, err := dbcommunity.GetId(data.Id)
-	if err == uppdb.ErrNoMoreRows {
-		return enums.ResponseCodes.BadRequest, nil
-	} else if err != nil {
-		return enums.ResponseCodes.ServerError, nil
-	}
-
-	community := realtime.NewCommunity(communityData.Id)
-
-	return enums.ResponseCodes.Ok, community.GetState()
+	return lookup.CommunityState(data.Id)
 }
@@ -12,7 +12,8 @@ import (
 
 func CommunityGetUsers(client Client, msg []byte) (int, interface{}) {
 	var data struct {
-		Id bson.ObjectId `json:"id"`
+		Id     bson.ObjectId `json:"id"`
+		Offset int           `json:"offset"`
 	}
 
 	if err := json.Unmarshal(msg, &data); err != nil {
@@ -28,5 +29,10 @@ func CommunityGetUsers(client Client, msg []byte) (int, interface{}) {
 
 	community := realtime.NewCommunity(communityData.Id)
 
-	return enums.ResponseCodes.Ok, community.Population
+	users, err := community.GetUsers(data.Offset, 100)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, users
 }
@@ -6,6 +6,7 @@ import (
 	"hybris/db/dbcommunity"
 	"hybris/enums"
 	"hybris/realtime"
+	"hybris/structs"
 	"time"
 
 	uppdb "upper.io/db"
@@ -42,8 +43,19 @@ func CommunityJoin(client Client, msg []byte) (int, interface{}) {
 		return enums.ResponseCodes.ServerError, nil
 	}
 
+	// Leave the previous community
+	if previous := client.GetRealtimeUser().GetCommunity(); previous != nil && previous.Id != community.Id {
+		previous.Leave(client.GetRealtimeUser().Id)
+	}
+
 	// Join community
 	community.Join(client.GetRealtimeUser().Id)
-	client.GetRealtimeUser().CommunityId = community.Id
-	return enums.ResponseCodes.Ok, communityData.Struct()
+	client.GetRealtimeUser().SetCommunityId(community.Id)
+
+	chats, err := chatHistory(community.Id, "", 50)
+	if err != nil {
+		chats = []structs.Chat{}
+	}
+
+	return enums.ResponseCodes.Ok, structs.CommunityJoinInfo{CommunityInfo: communityData.Struct(), Chat: chats}
 }
@@ -3,6 +3,7 @@ package clientaction
 import (
 	"encoding/json"
 	"hybris/enums"
+	"hybris/search"
 )
 
 func CommunitySearch(client Client, msg []byte) (int, interface{}) {
@@ -10,11 +11,21 @@ func CommunitySearch(client Client, msg []byte) (int, interface{}) {
 		Query            string `json:"query"`
 		Offset           int    `json:"offset"`
 		SortByPopulation bool   `json:"sortByPop"`
+		Nsfw             bool   `json:"nsfw"`
 	}
 
 	if err := json.Unmarshal(msg, &data); err != nil {
 		return enums.ResponseCodes.BadRequest, nil
 	}
 
-	return enums.ResponseCodes.Ok, nil
+	if len(data.Query) > 100 {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	communities, err := search.Community(data.Query, data.Offset, data.SortByPopulation, data.Nsfw)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, communities
 }
@@ -0,0 +1,124 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbcommunity"
+	"hybris/db/dbcommunitystaff"
+	"hybris/db/dbuser"
+	"hybris/debug"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/socket/message"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func CommunityTransferHost(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id     bson.ObjectId `json:"id"`
+		UserId bson.ObjectId `json:"userId"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	communityData, err := dbcommunity.LockGet(data.Id)
+	defer dbcommunity.Unlock(data.Id)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	hostId := client.GetRealtimeUser().Id
+	if communityData.HostId != hostId {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if data.UserId == hostId {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if _, err := dbuser.GetId(data.UserId); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	hosted, err := dbcommunity.GetMulti(-1, uppdb.Cond{"hostId": data.UserId})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if len(hosted) >= dbcommunity.MaxHosted {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if err := transferHost(communityData, data.UserId); err != nil {
+		debug.Log("Failed to transfer host of community %s: %s", communityData.Id, err.Error())
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	community := realtime.NewCommunity(communityData.Id)
+	community.Emit(message.NewEvent("community.transferHost", message.S{
+		"previousHostId": hostId,
+		"hostId":         data.UserId,
+	}))
+
+	return enums.ResponseCodes.Ok, communityData.Struct()
+}
+
+// Swaps the host of a community. The previous host becomes a co-host. Every
+// step is undone if a later one fails so the community doesn't end up with
+// zero or two hosts. Expects the community to be locked.
+func transferHost(communityData *dbcommunity.Community, newHostId bson.ObjectId) error {
+	previousHostId := communityData.HostId
+
+	oldStaff, err := dbcommunitystaff.Get(uppdb.Cond{"communityId": communityData.Id, "userId": previousHostId})
+	if err != nil {
+		return err
+	}
+
+	newStaff, err := dbcommunitystaff.Get(uppdb.Cond{"communityId": communityData.Id, "userId": newHostId})
+	if err == uppdb.ErrNoMoreRows {
+		if newStaff, err = dbcommunitystaff.New(communityData.Id, newHostId, enums.ModerationRoles.User); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	newRole := newStaff.Role
+
+	if err := setStaffRole(oldStaff, enums.ModerationRoles.CoHost); err != nil {
+		return err
+	}
+
+	if err := setStaffRole(newStaff, enums.ModerationRoles.Host); err != nil {
+		restoreStaffRole(oldStaff, enums.ModerationRoles.Host)
+		return err
+	}
+
+	communityData.HostId = newHostId
+	if err := communityData.Save(); err != nil {
+		communityData.HostId = previousHostId
+		restoreStaffRole(newStaff, newRole)
+		restoreStaffRole(oldStaff, enums.ModerationRoles.Host)
+		return err
+	}
+
+	return nil
+}
+
+// Undoes a role change of a failed host transfer. A failure leaves the
+// community with the wrong hosts, so it's logged for staff to fix by hand.
+func restoreStaffRole(staff dbcommunitystaff.CommunityStaff, role int) {
+	if err := setStaffRole(staff, role); err != nil {
+		debug.Log("Failed to restore role %d of user %s in community %s after a failed host transfer: %s",
+			role, staff.UserId, staff.CommunityId, err.Error())
+	}
+}
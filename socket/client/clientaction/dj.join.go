@@ -0,0 +1,61 @@
+package clientaction
+
+import (
+	"hybris/db/dbcommunity"
+	"hybris/db/dbplaylist"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func DjJoin(client Client, msg []byte) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	communityData, err := dbcommunity.GetId(community.Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if !communityData.WaitlistEnabled {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if status, ok := checkPlaylist(client.GetRealtimeUser().Id); !ok {
+		return status, nil
+	}
+
+	if !community.JoinWaitlist(client.GetRealtimeUser().Id) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
+
+// Checks that the user has a selected playlist with media to play. Returns the
+// response code to use when they don't.
+func checkPlaylist(userId bson.ObjectId) (int, bool) {
+	playlist, err := dbplaylist.Get(uppdb.Cond{"ownerId": userId, "selected": true})
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, false
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, false
+	}
+
+	items, err := playlist.GetItems()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, false
+	}
+
+	if len(items) <= 0 {
+		return enums.ResponseCodes.BadRequest, false
+	}
+
+	return enums.ResponseCodes.Ok, true
+}
@@ -0,0 +1,21 @@
+package clientaction
+
+import (
+	"hybris/enums"
+)
+
+func DjLeave(client Client, msg []byte) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !community.LeaveWaitlist(client.GetRealtimeUser().Id) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
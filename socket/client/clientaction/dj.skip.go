@@ -0,0 +1,21 @@
+package clientaction
+
+import (
+	"hybris/enums"
+)
+
+func DjSkip(client Client, msg []byte) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !community.Skip(client.GetRealtimeUser().Id) {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,67 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbblock"
+	"hybris/db/dbfollow"
+	"hybris/db/dbuser"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func DmBlock(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	if data.Id == client.GetRealtimeUser().Id {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if _, err := dbuser.GetId(data.Id); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if _, err := dbblock.Get(uppdb.Cond{"blockerId": client.GetRealtimeUser().Id, "blockedId": data.Id}); err == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	block, err := dbblock.New(client.GetRealtimeUser().Id, data.Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if err := block.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// Neither user follows the other anymore
+	follows, err := dbfollow.GetMulti(-1, uppdb.Or{
+		uppdb.Cond{"followerId": client.GetRealtimeUser().Id, "followeeId": data.Id},
+		uppdb.Cond{"followerId": data.Id, "followeeId": client.GetRealtimeUser().Id},
+	})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	for _, f := range follows {
+		if err := f.Delete(); err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
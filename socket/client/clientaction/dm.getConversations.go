@@ -0,0 +1,88 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbconversation"
+	"hybris/db/dbdirectmessage"
+	"hybris/db/dbuser"
+	"hybris/enums"
+	"hybris/structs"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func DmGetConversations(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Before bson.ObjectId `json:"before"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	query := uppdb.Cond{"ownerId": client.GetRealtimeUser().Id}
+	if data.Before != "" {
+		query["lastMessageId"] = bson.M{"$lt": data.Before}
+	}
+
+	conversations, err := dbconversation.GetLatest(50, query)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	payload := []structs.Conversation{}
+	if len(conversations) <= 0 {
+		return enums.ResponseCodes.Ok, payload
+	}
+
+	userIds := []bson.ObjectId{}
+	messageIds := []bson.ObjectId{}
+	for _, c := range conversations {
+		userIds = append(userIds, c.OtherId)
+		messageIds = append(messageIds, c.LastMessageId)
+	}
+
+	users, err := dbuser.GetMulti(-1, uppdb.Cond{"_id": bson.M{"$in": userIds}})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	messages, err := dbdirectmessage.GetMulti(-1, uppdb.Cond{"_id": bson.M{"$in": messageIds}})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	usersById := map[bson.ObjectId]dbuser.User{}
+	for _, u := range users {
+		usersById[u.Id] = u
+	}
+
+	messagesById := map[bson.ObjectId]dbdirectmessage.DirectMessage{}
+	for _, m := range messages {
+		messagesById[m.Id] = m
+	}
+
+	// The id of the last message of the last conversation is the next cursor
+	for _, c := range conversations {
+		user, ok := usersById[c.OtherId]
+		if !ok {
+			continue
+		}
+		last, ok := messagesById[c.LastMessageId]
+		if !ok {
+			continue
+		}
+
+		payload = append(payload, structs.Conversation{
+			User:   user.Struct(),
+			Last:   last.Struct(),
+			Unread: c.Unread,
+		})
+	}
+
+	return enums.ResponseCodes.Ok, payload
+}
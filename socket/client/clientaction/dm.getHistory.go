@@ -0,0 +1,48 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbdirectmessage"
+	"hybris/enums"
+	"hybris/structs"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func DmGetHistory(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id     bson.ObjectId `json:"id"`
+		Before bson.ObjectId `json:"before"`
+		Limit  int           `json:"limit"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if data.Limit <= 0 || data.Limit > 50 {
+		data.Limit = 50
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	var query interface{} = dbdirectmessage.Between(client.GetRealtimeUser().Id, data.Id)
+	if data.Before != "" {
+		query = uppdb.And{query, uppdb.Cond{"_id": bson.M{"$lt": data.Before}}}
+	}
+
+	messages, err := dbdirectmessage.GetRecent(data.Limit, query)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// Oldest first so the id of the first message is the next cursor
+	payload := make([]structs.DirectMessage, len(messages))
+	for i, dm := range messages {
+		payload[len(messages)-1-i] = dm.Struct()
+	}
+
+	return enums.ResponseCodes.Ok, payload
+}
@@ -0,0 +1,45 @@
+package clientaction
+
+import (
+	"hybris/db/dbblock"
+	"hybris/db/dbglobalban"
+	"hybris/db/dbuser"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+// Checks that two users can message each other. Neither can be globally banned
+// or have blocked the other. Returns the response code to use when they can't.
+func checkDirectMessage(senderId, recipientId bson.ObjectId) (int, bool) {
+	if senderId == recipientId {
+		return enums.ResponseCodes.BadRequest, false
+	}
+
+	if _, err := dbuser.GetId(recipientId); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, false
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, false
+	}
+
+	for _, id := range []bson.ObjectId{senderId, recipientId} {
+		banned, err := dbglobalban.Banned(id)
+		if err != nil {
+			return enums.ResponseCodes.ServerError, false
+		}
+		if banned {
+			return enums.ResponseCodes.Forbidden, false
+		}
+	}
+
+	blocked, err := dbblock.Blocked(senderId, recipientId)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, false
+	}
+	if blocked {
+		return enums.ResponseCodes.Forbidden, false
+	}
+
+	return enums.ResponseCodes.Ok, true
+}
@@ -0,0 +1,48 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbconversation"
+	"hybris/db/dbdirectmessage"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/socket/message"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func DmRead(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	userId := client.GetRealtimeUser().Id
+
+	ids, err := dbdirectmessage.MarkRead(uppdb.Cond{
+		"senderId":    data.Id,
+		"recipientId": userId,
+		"read":        false,
+	})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if err := dbconversation.MarkRead(userId, data.Id); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// Read receipt for the sender
+	if u, ok := realtime.GetUser(data.Id); ok && len(ids) > 0 {
+		u.Send(message.NewEvent("dm.read", message.S{"readerId": userId, "ids": ids}))
+	}
+
+	return enums.ResponseCodes.Ok, ids
+}
@@ -0,0 +1,55 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbconversation"
+	"hybris/db/dbdirectmessage"
+	"hybris/debug"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/socket/message"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func DmSend(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id      bson.ObjectId `json:"id"`
+		Message string        `json:"message"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	if status, ok := checkDirectMessage(client.GetRealtimeUser().Id, data.Id); !ok {
+		return status, nil
+	}
+
+	dm, err := dbdirectmessage.New(client.GetRealtimeUser().Id, data.Id, data.Message)
+	if err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if err := dm.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// The message is already sent, so a conversation that couldn't be updated
+	// only shows up late in the conversation list
+	if err := dbconversation.Record(dm.SenderId, dm.RecipientId, dm.Id, false); err != nil {
+		debug.Log("Failed to update conversation of user %s: %s", dm.SenderId, err.Error())
+	}
+	if err := dbconversation.Record(dm.RecipientId, dm.SenderId, dm.Id, true); err != nil {
+		debug.Log("Failed to update conversation of user %s: %s", dm.RecipientId, err.Error())
+	}
+
+	if u, ok := realtime.GetUser(data.Id); ok {
+		u.Send(message.NewEvent("dm.receive", dm.Struct()))
+	}
+
+	return enums.ResponseCodes.Ok, dm.Struct()
+}
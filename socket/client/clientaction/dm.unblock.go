@@ -0,0 +1,36 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbblock"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func DmUnblock(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	block, err := dbblock.Get(uppdb.Cond{"blockerId": client.GetRealtimeUser().Id, "blockedId": data.Id})
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if err := block.Delete(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
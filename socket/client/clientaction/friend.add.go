@@ -0,0 +1,59 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbblock"
+	"hybris/db/dbfollow"
+	"hybris/db/dbuser"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func FriendAdd(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	userId := client.GetRealtimeUser().Id
+	if data.Id == userId {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if _, err := dbuser.GetId(data.Id); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if blocked, err := dbblock.Blocked(userId, data.Id); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	} else if blocked {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if _, err := dbfollow.Get(uppdb.Cond{"followerId": userId, "followeeId": data.Id}); err == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	follow, err := dbfollow.New(userId, data.Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if err := follow.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,60 @@
+package clientaction
+
+import (
+	"hybris/db/dbblock"
+	"hybris/db/dbfollow"
+	"hybris/db/dbuser"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/structs"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func FriendList(client Client, msg []byte) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	follows, err := dbfollow.GetMulti(-1, uppdb.Cond{"followerId": client.GetRealtimeUser().Id})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// Follows are removed on block, but older ones may remain
+	blocked, err := dbblock.BlockedWith(client.GetRealtimeUser().Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	ids := []bson.ObjectId{}
+	for _, f := range follows {
+		if !blocked[f.FolloweeId] {
+			ids = append(ids, f.FolloweeId)
+		}
+	}
+
+	if len(ids) == 0 {
+		return enums.ResponseCodes.Ok, []structs.FriendInfo{}
+	}
+
+	users, err := dbuser.GetMulti(-1, uppdb.Cond{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	payload := make([]structs.FriendInfo, len(users))
+	for i, user := range users {
+		payload[i] = structs.FriendInfo{
+			UserInfo: user.Struct(),
+			Status:   enums.UserStatuses.Offline,
+		}
+
+		if u, ok := realtime.GetUser(user.Id); ok {
+			payload[i].Status = u.GetStatus()
+			payload[i].CommunityId = u.GetCommunityId()
+		}
+	}
+
+	return enums.ResponseCodes.Ok, payload
+}
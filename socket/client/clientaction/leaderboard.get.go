@@ -0,0 +1,26 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/economy"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func LeaderboardGet(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		CommunityId bson.ObjectId `json:"communityId"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	leaderboard, err := economy.Leaderboard(data.CommunityId)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, leaderboard
+}
@@ -0,0 +1,56 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbmedia"
+	"hybris/db/dbplaylist"
+	"hybris/debug"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func MediaAdd(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		PlaylistId bson.ObjectId `json:"playlistId"`
+		Type       int           `json:"type"`
+		MediaId    string        `json:"mid"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	playlist, err := dbplaylist.LockGet(data.PlaylistId)
+	defer dbplaylist.Unlock(data.PlaylistId)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if playlist.OwnerId != client.GetRealtimeUser().Id {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	media, err := dbmedia.Resolve(data.MediaId, data.Type)
+	if err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	item, err := playlist.AddItem(media.Id, media.Title, media.Artist)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// The item is already saved, so a wrong count shouldn't fail the add
+	if err := dbmedia.AddPlaylists(media.Id, 1); err != nil {
+		debug.Log("Failed to update playlist count of media %s: %s", media.Id, err.Error())
+	}
+
+	return enums.ResponseCodes.Ok, item.Struct()
+}
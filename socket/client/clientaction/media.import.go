@@ -0,0 +1,155 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbmedia"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbplaylistitem"
+	"hybris/db/dbuser"
+	"hybris/debug"
+	"hybris/downloader"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/socket/message"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func MediaImport(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Type int    `json:"type"`
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if len(data.Id) <= 0 {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	var resolve func(string) ([]string, error)
+	switch data.Type {
+	case enums.MediaTypes.Youtube:
+		resolve = downloader.YoutubePlaylist
+	case enums.MediaTypes.Soundcloud:
+		resolve = downloader.SoundcloudSet
+	default:
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	user, err := dbuser.GetId(client.GetRealtimeUser().Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlists, err := user.GetPlaylists()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlist, err := dbplaylist.New(data.Name, user.Id)
+	if err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	playlist.Order = len(playlists)
+	playlist.Selected = len(playlists) == 0
+
+	if err := playlist.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	go importMedia(user.Id, playlist, data.Type, data.Id, resolve)
+
+	return enums.ResponseCodes.Ok, playlist.Struct()
+}
+
+type importFailure struct {
+	MediaId string `json:"mid"`
+	Reason  string `json:"reason"`
+}
+
+// Sends an event to the user if they're online. The user is looked up every
+// time since they may reconnect during a long import.
+func sendImportEvent(userId bson.ObjectId, name string, data message.S) {
+	if u, ok := realtime.GetUser(userId); ok {
+		u.Send(message.NewEvent(name, data))
+	}
+}
+
+func importMedia(userId bson.ObjectId, playlist dbplaylist.Playlist, platform int, id string, resolve func(string) ([]string, error)) {
+	debug.Log("Importing %s into playlist %s", id, playlist.Id)
+
+	failed := []importFailure{}
+	finish := func(imported int) {
+		sendImportEvent(userId, "media.import.finish", message.S{
+			"playlistId": playlist.Id,
+			"imported":   imported,
+			"failed":     failed,
+		})
+	}
+
+	mids, err := resolve(id)
+	if err != nil {
+		debug.Log("Failed to resolve %s for import into playlist %s: %s", id, playlist.Id, err.Error())
+		failed = append(failed, importFailure{id, err.Error()})
+		finish(0)
+		return
+	}
+
+	items := []dbplaylistitem.PlaylistItem{}
+	for i, mid := range mids {
+		media, err := dbmedia.Resolve(mid, platform)
+		if err != nil {
+			failed = append(failed, importFailure{mid, err.Error()})
+		} else if item, err := dbplaylistitem.New(playlist.Id, media.Id, media.Title, media.Artist); err != nil {
+			failed = append(failed, importFailure{mid, err.Error()})
+		} else {
+			items = append(items, item)
+		}
+
+		sendImportEvent(userId, "media.import.progress", message.S{
+			"playlistId": playlist.Id,
+			"done":       i + 1,
+			"total":      len(mids),
+			"failed":     len(failed),
+		})
+	}
+
+	p, err := dbplaylist.LockGet(playlist.Id)
+	if err != nil {
+		dbplaylist.Unlock(playlist.Id)
+		debug.Log("Failed to retrieve playlist %s after import: %s", playlist.Id, err.Error())
+		failed = append(failed, importFailure{id, err.Error()})
+		finish(0)
+		return
+	}
+
+	existing, err := p.GetItems()
+	if err == nil {
+		err = p.SaveItems(append(existing, items...))
+	}
+	dbplaylist.Unlock(playlist.Id)
+
+	if err != nil {
+		debug.Log("Failed to save imported items into playlist %s: %s", playlist.Id, err.Error())
+		failed = append(failed, importFailure{id, err.Error()})
+		finish(0)
+		return
+	}
+
+	for _, item := range items {
+		if err := dbmedia.AddPlaylists(item.MediaId, 1); err != nil {
+			debug.Log("Failed to update playlist count of media %s: %s", item.MediaId, err.Error())
+		}
+	}
+
+	debug.Log("Finished importing %s into playlist %s", id, playlist.Id)
+	finish(len(items))
+}
@@ -0,0 +1,75 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbmedia"
+	"hybris/downloader"
+	"hybris/enums"
+	"hybris/structs"
+	"regexp"
+	"strings"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func MediaSearch(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Query    string `json:"query"`
+		Upstream bool   `json:"upstream"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	query := strings.TrimSpace(data.Query)
+	if len(query) <= 0 || len(query) > 100 {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	pattern := bson.RegEx{Pattern: regexp.QuoteMeta(query), Options: "i"}
+	media, err := dbmedia.GetMulti(50, uppdb.Or{
+		uppdb.Cond{"title": pattern},
+		uppdb.Cond{"artist": pattern},
+	})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	found := map[string]bool{}
+	results := []structs.SearchResult{}
+	for _, m := range media {
+		found[m.MediaId] = true
+		results = append(results, structs.SearchResult{
+			Image:   m.Image,
+			Artist:  m.Artist,
+			Title:   m.Title,
+			Type:    m.Type,
+			MediaId: m.MediaId,
+		})
+	}
+
+	if !data.Upstream {
+		return enums.ResponseCodes.Ok, results
+	}
+
+	for _, search := range []func(string) ([]structs.SearchResult, error){
+		downloader.YoutubeSearch,
+		downloader.SoundcloudSearch,
+	} {
+		upstream, err := search(query)
+		if err != nil {
+			continue
+		}
+
+		for _, r := range upstream {
+			if !found[r.MediaId] {
+				found[r.MediaId] = true
+				results = append(results, r)
+			}
+		}
+	}
+
+	return enums.ResponseCodes.Ok, results
+}
@@ -0,0 +1,42 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func ModerationAddDj(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !community.HasPermission(client.GetRealtimeUser().Id, enums.ModerationRoles.Bouncer) {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if status, ok := checkPlaylist(data.Id); !ok {
+		return status, nil
+	}
+
+	if !community.AddDj(data.Id) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	emitBooth(community, "moderation.addDj", client.GetRealtimeUser().Id, data.Id)
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,79 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbban"
+	"hybris/db/dbuser"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/socket/message"
+	"hybris/validation"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func ModerationBan(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id       bson.ObjectId `json:"id"`
+		Duration int           `json:"duration"`
+		Reason   string        `json:"reason"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !validation.Reason(data.Reason) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if _, err := dbuser.GetId(data.Id); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	// Permanent bans are reserved for managers
+	required := enums.ModerationRoles.Bouncer
+	if data.Duration <= 0 {
+		required = enums.ModerationRoles.Manager
+	}
+
+	if status, ok := checkModeration(community, client.GetRealtimeUser().Id, data.Id, required); !ok {
+		return status, nil
+	}
+
+	ban, err := dbban.New(data.Id, client.GetRealtimeUser().Id, community.Id, data.Reason, until(data.Duration))
+	if err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if existing, err := dbban.Get(uppdb.Cond{"banneeId": data.Id, "communityId": community.Id}); err == nil {
+		if err := existing.Delete(); err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+	} else if err != uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if err := ban.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	evt := message.NewEvent("moderation.ban", ban.Struct())
+	community.Emit(evt)
+	if u, ok := realtime.GetUser(data.Id); ok && u.GetCommunityId() == community.Id {
+		community.Evict(data.Id, client.GetRealtimeUser().Id)
+	}
+
+	return enums.ResponseCodes.Ok, ban.Struct()
+}
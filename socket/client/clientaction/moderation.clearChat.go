@@ -0,0 +1,47 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbchat"
+	"hybris/enums"
+	"hybris/socket/message"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func ModerationClearChat(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		UserId bson.ObjectId `json:"userId"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !community.HasPermission(client.GetRealtimeUser().Id, enums.ModerationRoles.Bouncer) {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	query := uppdb.Cond{"communityId": community.Id, "deleted": false}
+	if data.UserId != "" {
+		query["userId"] = data.UserId
+	}
+
+	ids, err := dbchat.DeleteMulti(query, client.GetRealtimeUser().Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	community.Emit(message.NewEvent("chat.clear", message.S{"ids": ids, "deleter": client.GetRealtimeUser().Id}))
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,27 @@
+package clientaction
+
+import (
+	"hybris/enums"
+)
+
+func ModerationForceSkip(client Client, msg []byte) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !community.HasPermission(client.GetRealtimeUser().Id, enums.ModerationRoles.Bouncer) {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if !community.ForceSkip(client.GetRealtimeUser().Id) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	emitBooth(community, "moderation.forceSkip", client.GetRealtimeUser().Id, "")
+
+	return enums.ResponseCodes.Ok, nil
+}
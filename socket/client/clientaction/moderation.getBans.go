@@ -0,0 +1,37 @@
+package clientaction
+
+import (
+	"hybris/db/dbban"
+	"hybris/enums"
+	"time"
+
+	uppdb "upper.io/db"
+)
+
+func ModerationGetBans(client Client, msg []byte) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !community.HasPermission(client.GetRealtimeUser().Id, enums.ModerationRoles.Bouncer) {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	bans, err := dbban.GetMulti(-1, uppdb.Cond{"communityId": community.Id})
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	active := []dbban.Ban{}
+	for _, ban := range bans {
+		if ban.Until == nil || ban.Until.After(time.Now()) {
+			active = append(active, ban)
+		}
+	}
+
+	return enums.ResponseCodes.Ok, dbban.StructMulti(active)
+}
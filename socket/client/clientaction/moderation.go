@@ -0,0 +1,59 @@
+package clientaction
+
+import (
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/socket/message"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Checks that the moderator has at least the required role in the community
+// and outranks the target. Returns the response code to use when they can't
+// moderate the target.
+func checkModeration(community *realtime.Community, moderatorId, targetId bson.ObjectId, required int) (int, bool) {
+	if moderatorId == targetId {
+		return enums.ResponseCodes.BadRequest, false
+	}
+
+	if !community.HasPermission(moderatorId, required) {
+		return enums.ResponseCodes.Forbidden, false
+	}
+
+	role, err := community.GetRole(targetId)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, false
+	}
+
+	if !community.HasPermission(moderatorId, role+1) {
+		return enums.ResponseCodes.Forbidden, false
+	}
+
+	return enums.ResponseCodes.Ok, true
+}
+
+// Converts a duration in seconds to an expiry time. A duration of 0 or less
+// never expires.
+func until(duration int) *time.Time {
+	if duration <= 0 {
+		return nil
+	}
+	t := time.Now().Add(time.Duration(duration) * time.Second)
+	return &t
+}
+
+// Broadcasts a booth moderation event with the new community state
+func emitBooth(community *realtime.Community, name string, moderatorId, userId bson.ObjectId) {
+	community.Lock()
+	defer community.Unlock()
+
+	data := message.S{
+		"moderatorId": moderatorId,
+		"state":       community.GetState(),
+	}
+	if userId != "" {
+		data["userId"] = userId
+	}
+	community.Emit(message.NewEvent(name, data))
+}
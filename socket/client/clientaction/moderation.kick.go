@@ -0,0 +1,76 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbban"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/socket/message"
+	"hybris/validation"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func ModerationKick(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id       bson.ObjectId `json:"id"`
+		Duration int           `json:"duration"`
+		Reason   string        `json:"reason"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !validation.Reason(data.Reason) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	u, ok := realtime.GetUser(data.Id)
+	if !ok || u.GetCommunityId() != community.Id {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if status, ok := checkModeration(community, client.GetRealtimeUser().Id, data.Id, enums.ModerationRoles.Bouncer); !ok {
+		return status, nil
+	}
+
+	// A kick with a duration keeps the user out until it expires
+	if data.Duration > 0 {
+		ban, err := dbban.New(data.Id, client.GetRealtimeUser().Id, community.Id, data.Reason, until(data.Duration))
+		if err != nil {
+			return enums.ResponseCodes.BadRequest, nil
+		}
+
+		if existing, err := dbban.Get(uppdb.Cond{"banneeId": data.Id, "communityId": community.Id}); err == nil {
+			if err := existing.Delete(); err != nil {
+				return enums.ResponseCodes.ServerError, nil
+			}
+		} else if err != uppdb.ErrNoMoreRows {
+			return enums.ResponseCodes.ServerError, nil
+		}
+
+		if err := ban.Save(); err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+	}
+
+	community.Emit(message.NewEvent("moderation.kick", message.S{
+		"kicker": client.GetRealtimeUser().Id,
+		"kickee": data.Id,
+		"reason": data.Reason,
+		"until":  until(data.Duration),
+	}))
+	community.Evict(data.Id, client.GetRealtimeUser().Id)
+
+	return enums.ResponseCodes.Ok, nil
+}
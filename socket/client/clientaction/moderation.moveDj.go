@@ -0,0 +1,39 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func ModerationMoveDj(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id       bson.ObjectId `json:"id"`
+		Position int           `json:"position"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !community.HasPermission(client.GetRealtimeUser().Id, enums.ModerationRoles.Bouncer) {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if !community.MoveDj(data.Id, data.Position) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	emitBooth(community, "moderation.moveDj", client.GetRealtimeUser().Id, data.Id)
+
+	return enums.ResponseCodes.Ok, nil
+}
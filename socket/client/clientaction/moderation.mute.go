@@ -0,0 +1,73 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbmute"
+	"hybris/db/dbuser"
+	"hybris/enums"
+	"hybris/realtime"
+	"hybris/socket/message"
+	"hybris/validation"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func ModerationMute(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id       bson.ObjectId `json:"id"`
+		Duration int           `json:"duration"`
+		Reason   string        `json:"reason"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !validation.Reason(data.Reason) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if _, err := dbuser.GetId(data.Id); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if status, ok := checkModeration(community, client.GetRealtimeUser().Id, data.Id, enums.ModerationRoles.Bouncer); !ok {
+		return status, nil
+	}
+
+	mute, err := dbmute.New(data.Id, client.GetRealtimeUser().Id, community.Id, data.Reason, until(data.Duration))
+	if err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if existing, err := dbmute.Get(uppdb.Cond{"muteeId": data.Id, "communityId": community.Id}); err == nil {
+		if err := existing.Delete(); err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+	} else if err != uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if err := mute.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	evt := message.NewEvent("moderation.mute", mute.Struct())
+	community.Emit(evt)
+	if u, ok := realtime.GetUser(data.Id); ok && u.GetCommunityId() != community.Id {
+		u.Send(evt)
+	}
+
+	return enums.ResponseCodes.Ok, mute.Struct()
+}
@@ -0,0 +1,38 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func ModerationRemoveDj(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if status, ok := checkModeration(community, client.GetRealtimeUser().Id, data.Id, enums.ModerationRoles.Bouncer); !ok {
+		return status, nil
+	}
+
+	if !community.RemoveDj(data.Id, client.GetRealtimeUser().Id) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	emitBooth(community, "moderation.removeDj", client.GetRealtimeUser().Id, data.Id)
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,105 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbcommunitystaff"
+	"hybris/db/dbuser"
+	"hybris/enums"
+	"hybris/socket/message"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func ModerationSetRole(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id   bson.ObjectId `json:"id"`
+		Role int           `json:"role"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	// The host can only be changed with community.transferHost
+	if data.Role < enums.ModerationRoles.User || data.Role >= enums.ModerationRoles.Host {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if _, err := dbuser.GetId(data.Id); err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	moderatorId := client.GetRealtimeUser().Id
+	if status, ok := checkModeration(community, moderatorId, data.Id, enums.ModerationRoles.Bouncer); !ok {
+		return status, nil
+	}
+
+	// Nobody can grant a role at or above their own
+	if !community.HasPermission(moderatorId, data.Role+1) {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if data.Role == enums.ModerationRoles.CoHost {
+		role, err := community.GetRole(moderatorId)
+		if err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+		if role != enums.ModerationRoles.Host {
+			return enums.ResponseCodes.Forbidden, nil
+		}
+	}
+
+	staff, err := dbcommunitystaff.Get(uppdb.Cond{"communityId": community.Id, "userId": data.Id})
+	if err == uppdb.ErrNoMoreRows {
+		if staff, err = dbcommunitystaff.New(community.Id, data.Id, enums.ModerationRoles.User); err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if err := setStaffRole(staff, data.Role); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	community.Emit(message.NewEvent("moderation.setRole", message.S{
+		"moderatorId": moderatorId,
+		"userId":      data.Id,
+		"role":        data.Role,
+	}))
+
+	return enums.ResponseCodes.Ok, nil
+}
+
+// Persists a staff role. A role of User removes the staff row.
+func setStaffRole(staff dbcommunitystaff.CommunityStaff, role int) error {
+	existing, err := dbcommunitystaff.LockGet(staff.Id)
+	defer dbcommunitystaff.Unlock(staff.Id)
+	if err == uppdb.ErrNoMoreRows {
+		if role == enums.ModerationRoles.User {
+			return nil
+		}
+		staff.Role = role
+		return staff.Save()
+	} else if err != nil {
+		return err
+	}
+
+	if role == enums.ModerationRoles.User {
+		return existing.Delete()
+	}
+
+	existing.Role = role
+	return existing.Save()
+}
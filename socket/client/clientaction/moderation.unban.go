@@ -0,0 +1,51 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbban"
+	"hybris/enums"
+	"hybris/socket/message"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func ModerationUnban(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if !community.HasPermission(client.GetRealtimeUser().Id, enums.ModerationRoles.Bouncer) {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	ban, err := dbban.Get(uppdb.Cond{"banneeId": data.Id, "communityId": community.Id})
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if err := ban.Delete(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	community.Emit(message.NewEvent("moderation.unban", message.S{
+		"unbanner": client.GetRealtimeUser().Id,
+		"unbannee": data.Id,
+	}))
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,60 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbuser"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func PlaylistActivate(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	user, err := dbuser.GetId(client.GetRealtimeUser().Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlists, err := user.GetPlaylists()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	found := false
+	for i, p := range playlists {
+		playlists[i].Selected = p.Id == data.Id
+		found = found || p.Id == data.Id
+	}
+
+	if !found {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	for _, p := range playlists {
+		playlist, err := dbplaylist.LockGet(p.Id)
+		if err != nil {
+			dbplaylist.Unlock(p.Id)
+			return enums.ResponseCodes.ServerError, nil
+		}
+
+		playlist.Selected = p.Selected
+		err = playlist.Save()
+		dbplaylist.Unlock(p.Id)
+		if err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+	}
+
+	return enums.ResponseCodes.Ok, dbplaylist.StructMulti(playlists)
+}
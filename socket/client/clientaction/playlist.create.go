@@ -0,0 +1,45 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbuser"
+	"hybris/enums"
+)
+
+func PlaylistCreate(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	user, err := dbuser.GetId(client.GetRealtimeUser().Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlists, err := user.GetPlaylists()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlist, err := dbplaylist.New(data.Name, user.Id)
+	if err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	playlist.Order = len(playlists)
+	playlist.Selected = len(playlists) == 0
+
+	if err := playlist.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, playlist.Struct()
+}
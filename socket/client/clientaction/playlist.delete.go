@@ -0,0 +1,84 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbmedia"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbuser"
+	"hybris/debug"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func PlaylistDelete(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	playlist, err := dbplaylist.LockGet(data.Id)
+	defer dbplaylist.Unlock(data.Id)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if playlist.OwnerId != client.GetRealtimeUser().Id {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	user, err := dbuser.GetId(client.GetRealtimeUser().Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlists, err := user.GetPlaylists()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	items, err := playlist.GetItems()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	for _, item := range items {
+		if err := item.Delete(); err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+
+		if err := dbmedia.AddPlaylists(item.MediaId, -1); err != nil {
+			debug.Log("Failed to update playlist count of media %s: %s", item.MediaId, err.Error())
+		}
+	}
+
+	if err := playlist.Delete(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	remaining := []dbplaylist.Playlist{}
+	for _, p := range playlists {
+		if p.Id != playlist.Id {
+			remaining = append(remaining, p)
+		}
+	}
+
+	if playlist.Selected && len(remaining) > 0 {
+		remaining[0].Selected = true
+	}
+
+	if err := user.SavePlaylists(remaining); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,51 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbplaylist"
+	"hybris/enums"
+	"hybris/validation"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func PlaylistEdit(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id   bson.ObjectId `json:"id"`
+		Name *string       `json:"name"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	playlist, err := dbplaylist.LockGet(data.Id)
+	defer dbplaylist.Unlock(data.Id)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if playlist.OwnerId != client.GetRealtimeUser().Id {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if data.Name != nil {
+		name := *data.Name
+		if !validation.PlaylistName(name) {
+			return enums.ResponseCodes.BadRequest, nil
+		}
+		playlist.Name = name
+	}
+
+	if err := playlist.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, playlist.Struct()
+}
@@ -0,0 +1,42 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbplaylistitem"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func PlaylistGet(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id bson.ObjectId `json:"id"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	playlist, err := dbplaylist.GetId(data.Id)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if playlist.OwnerId != client.GetRealtimeUser().Id {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	items, err := playlist.GetItems()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, dbplaylistitem.StructMulti(items)
+}
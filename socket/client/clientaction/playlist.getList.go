@@ -0,0 +1,24 @@
+package clientaction
+
+import (
+	"hybris/db/dbplaylist"
+	"hybris/db/dbuser"
+	"hybris/enums"
+)
+
+func PlaylistGetList(client Client, msg []byte) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	user, err := dbuser.GetId(client.GetRealtimeUser().Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlists, err := user.GetPlaylists()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, dbplaylist.StructMulti(playlists)
+}
@@ -0,0 +1,73 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbuser"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func PlaylistMove(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id       bson.ObjectId `json:"id"`
+		Position int           `json:"position"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	user, err := dbuser.GetId(client.GetRealtimeUser().Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlists, err := user.GetPlaylists()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	var (
+		moving    *dbplaylist.Playlist
+		remaining []dbplaylist.Playlist
+	)
+
+	for i, p := range playlists {
+		if p.Id == data.Id {
+			moving = &playlists[i]
+		} else {
+			remaining = append(remaining, p)
+		}
+	}
+
+	if moving == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	position := data.Position
+	if position < 0 {
+		position = 0
+	} else if position > len(remaining) {
+		position = len(remaining)
+	}
+
+	payload := append([]dbplaylist.Playlist{}, remaining[:position]...)
+	payload = append(payload, *moving)
+	payload = append(payload, remaining[position:]...)
+
+	if err := user.SavePlaylists(payload); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlists, err = user.GetPlaylists()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, dbplaylist.StructMulti(playlists)
+}
@@ -0,0 +1,71 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbmedia"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbplaylistitem"
+	"hybris/debug"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func PlaylistItemDelete(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		PlaylistId bson.ObjectId   `json:"playlistId"`
+		Ids        []bson.ObjectId `json:"ids"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	playlist, err := dbplaylist.LockGet(data.PlaylistId)
+	defer dbplaylist.Unlock(data.PlaylistId)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if playlist.OwnerId != client.GetRealtimeUser().Id {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	items, err := playlist.GetItems()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	deleting := map[bson.ObjectId]bool{}
+	for _, id := range data.Ids {
+		deleting[id] = true
+	}
+
+	remaining := []dbplaylistitem.PlaylistItem{}
+	for _, item := range items {
+		if !deleting[item.Id] {
+			remaining = append(remaining, item)
+			continue
+		}
+
+		if err := item.Delete(); err != nil {
+			return enums.ResponseCodes.ServerError, nil
+		}
+
+		if err := dbmedia.AddPlaylists(item.MediaId, -1); err != nil {
+			debug.Log("Failed to update playlist count of media %s: %s", item.MediaId, err.Error())
+		}
+	}
+
+	if err := playlist.SaveItems(remaining); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,66 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbplaylistitem"
+	"hybris/enums"
+	"hybris/validation"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func PlaylistItemEdit(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Id     bson.ObjectId `json:"id"`
+		Title  *string       `json:"title"`
+		Artist *string       `json:"artist"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	item, err := dbplaylistitem.LockGet(data.Id)
+	defer dbplaylistitem.Unlock(data.Id)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlist, err := dbplaylist.GetId(item.PlaylistId)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if playlist.OwnerId != client.GetRealtimeUser().Id {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	if data.Title != nil {
+		title := *data.Title
+		if !validation.PlaylistItemTitle(title) {
+			return enums.ResponseCodes.BadRequest, nil
+		}
+		item.Title = title
+	}
+
+	if data.Artist != nil {
+		artist := *data.Artist
+		if !validation.PlaylistItemArtist(artist) {
+			return enums.ResponseCodes.BadRequest, nil
+		}
+		item.Artist = artist
+	}
+
+	if err := item.Save(); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, item.Struct()
+}
@@ -0,0 +1,83 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/db/dbplaylist"
+	"hybris/db/dbplaylistitem"
+	"hybris/enums"
+
+	"gopkg.in/mgo.v2/bson"
+	uppdb "upper.io/db"
+)
+
+func PlaylistItemMove(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		PlaylistId bson.ObjectId   `json:"playlistId"`
+		Ids        []bson.ObjectId `json:"ids"`
+		Position   int             `json:"position"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	playlist, err := dbplaylist.LockGet(data.PlaylistId)
+	defer dbplaylist.Unlock(data.PlaylistId)
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	if playlist.OwnerId != client.GetRealtimeUser().Id {
+		return enums.ResponseCodes.Forbidden, nil
+	}
+
+	items, err := playlist.GetItems()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	ids := map[bson.ObjectId]bool{}
+	for _, id := range data.Ids {
+		ids[id] = true
+	}
+
+	var moving, remaining []dbplaylistitem.PlaylistItem
+	for _, item := range items {
+		if ids[item.Id] {
+			moving = append(moving, item)
+		} else {
+			remaining = append(remaining, item)
+		}
+	}
+
+	if len(moving) != len(ids) {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	position := data.Position
+	if position < 0 {
+		position = 0
+	} else if position > len(remaining) {
+		position = len(remaining)
+	}
+
+	payload := append([]dbplaylistitem.PlaylistItem{}, remaining[:position]...)
+	payload = append(payload, moving...)
+	payload = append(payload, remaining[position:]...)
+
+	if err := playlist.SaveItems(payload); err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	items, err = playlist.GetItems()
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	return enums.ResponseCodes.Ok, dbplaylistitem.StructMulti(items)
+}
@@ -0,0 +1,19 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/enums"
+	"hybris/lookup"
+)
+
+func UserGetProfile(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Username string `json:"username"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	return lookup.UserProfile(data.Username)
+}
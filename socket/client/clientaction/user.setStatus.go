@@ -0,0 +1,29 @@
+package clientaction
+
+import (
+	"encoding/json"
+	"hybris/enums"
+)
+
+func UserSetStatus(client Client, msg []byte) (int, interface{}) {
+	var data struct {
+		Status string `json:"status"`
+	}
+
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	switch data.Status {
+	case enums.UserStatuses.Online, enums.UserStatuses.Away, enums.UserStatuses.Busy:
+	default:
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	client.GetRealtimeUser().SetStatus(data.Status)
+
+	return enums.ResponseCodes.Ok, nil
+}
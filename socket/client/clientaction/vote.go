@@ -0,0 +1,21 @@
+package clientaction
+
+import (
+	"hybris/enums"
+)
+
+func vote(client Client, voteType int) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	if _, ok := community.Vote(client.GetRealtimeUser().Id, voteType); !ok {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	return enums.ResponseCodes.Ok, nil
+}
@@ -0,0 +1,9 @@
+package clientaction
+
+import (
+	"hybris/enums"
+)
+
+func VoteMeh(client Client, msg []byte) (int, interface{}) {
+	return vote(client, enums.VoteTypes.Meh)
+}
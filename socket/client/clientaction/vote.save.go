@@ -0,0 +1,40 @@
+package clientaction
+
+import (
+	"hybris/db/dbplaylist"
+	"hybris/enums"
+
+	uppdb "upper.io/db"
+)
+
+func VoteSave(client Client, msg []byte) (int, interface{}) {
+	client.Lock()
+	defer client.Unlock()
+
+	community := client.GetRealtimeUser().GetCommunity()
+	if community == nil {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	selected, err := dbplaylist.Get(uppdb.Cond{"ownerId": client.GetRealtimeUser().Id, "selected": true})
+	if err == uppdb.ErrNoMoreRows {
+		return enums.ResponseCodes.BadRequest, nil
+	} else if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	playlist, err := dbplaylist.LockGet(selected.Id)
+	defer dbplaylist.Unlock(selected.Id)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	}
+
+	item, ok, err := community.SaveVote(client.GetRealtimeUser().Id, *playlist)
+	if err != nil {
+		return enums.ResponseCodes.ServerError, nil
+	} else if !ok {
+		return enums.ResponseCodes.BadRequest, nil
+	}
+
+	return enums.ResponseCodes.Ok, item.Struct()
+}
@@ -0,0 +1,9 @@
+package clientaction
+
+import (
+	"hybris/enums"
+)
+
+func VoteWoot(client Client, msg []byte) (int, interface{}) {
+	return vote(client, enums.VoteTypes.Woot)
+}
@@ -7,9 +7,11 @@ import (
 )
 
 type Chat struct {
-	Id      bson.ObjectId `json:"id"`
-	UserId  bson.ObjectId `json:"userId"`
-	Me      bool          `json:"me"`
-	Message string        `json:"message"`
-	Time    time.Time     `json:"time"`
+	Id       bson.ObjectId   `json:"id"`
+	UserId   bson.ObjectId   `json:"userId"`
+	Me       bool            `json:"me"`
+	Message  string          `json:"message"`
+	Mentions []bson.ObjectId `json:"mentions"`
+	Links    []string        `json:"links"`
+	Time     time.Time       `json:"time"`
 }
@@ -0,0 +1,7 @@
+package structs
+
+type ChatFilterRule struct {
+	Pattern string `json:"pattern"`
+	Regex   bool   `json:"regex"`
+	Action  int    `json:"action"`
+}
@@ -0,0 +1,6 @@
+package structs
+
+type CommunityJoinInfo struct {
+	CommunityInfo
+	Chat []Chat `json:"chat"`
+}
@@ -0,0 +1,9 @@
+package structs
+
+type CommunitySettings struct {
+	CommunityInfo
+	ChatLimit       int              `json:"chatLimit"`
+	ChatLimitPeriod int              `json:"chatLimitPeriod"`
+	ChatFilter      []ChatFilterRule `json:"chatFilter"`
+	LinksDisabled   bool             `json:"linksDisabled"`
+}
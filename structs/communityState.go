@@ -5,4 +5,5 @@ import "gopkg.in/mgo.v2/bson"
 type CommunityState struct {
 	Waitlist   []bson.ObjectId       `json:"waitlist"`
 	NowPlaying *CommunityPlayingInfo `json:"nowPlaying"`
+	Seq        int                   `json:"seq"`
 }
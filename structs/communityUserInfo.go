@@ -0,0 +1,7 @@
+package structs
+
+type CommunityUserInfo struct {
+	UserInfo
+	Role   int    `json:"role"`
+	Status string `json:"status"`
+}
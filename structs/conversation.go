@@ -0,0 +1,7 @@
+package structs
+
+type Conversation struct {
+	User   UserInfo      `json:"user"`
+	Last   DirectMessage `json:"last"`
+	Unread int           `json:"unread"`
+}
@@ -0,0 +1,16 @@
+package structs
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+type DirectMessage struct {
+	Id          bson.ObjectId `json:"id"`
+	SenderId    bson.ObjectId `json:"senderId"`
+	RecipientId bson.ObjectId `json:"recipientId"`
+	Message     string        `json:"message"`
+	Read        bool          `json:"read"`
+	Time        time.Time     `json:"time"`
+}
@@ -0,0 +1,9 @@
+package structs
+
+import "gopkg.in/mgo.v2/bson"
+
+type FriendInfo struct {
+	UserInfo
+	Status      string        `json:"status"`
+	CommunityId bson.ObjectId `json:"communityId,omitempty"`
+}
@@ -1,11 +1,16 @@
 package structs
 
 import (
+	"time"
+
 	"gopkg.in/mgo.v2/bson"
 )
 
 type HistoryItem struct {
-	Dj    bson.ObjectId     `json:"dj"`
-	Media ResolvedMediaInfo `json:"media"`
-	Votes VoteCount         `json:"votes"`
+	Id          bson.ObjectId     `json:"id"`
+	Time        time.Time         `json:"time"`
+	Dj          UserInfo          `json:"dj"`
+	ModeratorId bson.ObjectId     `json:"moderatorId,omitempty"`
+	Media       ResolvedMediaInfo `json:"media"`
+	Votes       VoteCount         `json:"votes"`
 }
@@ -1,7 +1,7 @@
 package structs
 
 type LandingCommunityListing struct {
-	Population int                      `json:"population"`
-	Playing    CommunityFullPlayingInfo `json:"playing"`
-	Info       CommunityFullInfo        `json:"info"`
+	Population int                       `json:"population"`
+	Playing    *CommunityFullPlayingInfo `json:"playing"`
+	Info       CommunityFullInfo         `json:"info"`
 }
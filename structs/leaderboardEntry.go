@@ -0,0 +1,7 @@
+package structs
+
+type LeaderboardEntry struct {
+	User   UserInfo `json:"user"`
+	Rank   int      `json:"rank"`
+	Points int      `json:"points"`
+}
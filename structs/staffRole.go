@@ -0,0 +1,8 @@
+package structs
+
+import "gopkg.in/mgo.v2/bson"
+
+type StaffRole struct {
+	CommunityId bson.ObjectId `json:"communityId"`
+	Role        int           `json:"role"`
+}
@@ -0,0 +1,11 @@
+package structs
+
+type UserProfile struct {
+	UserInfo
+	Plays   int             `json:"plays"`
+	Woots   int             `json:"woots"`
+	Mehs    int             `json:"mehs"`
+	History []HistoryItem   `json:"history"`
+	Hosted  []CommunityInfo `json:"hosted"`
+	Staff   []StaffRole     `json:"staff"`
+}
@@ -3,6 +3,7 @@ package validation
 import (
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 var ReservedEmails = []string{
@@ -85,14 +86,63 @@ func CommunityWelcomeMessage(welcomeMessage string) (valid bool) {
 	return
 }
 
+func ChatLimit(chatLimit int) (valid bool) {
+	valid = !(chatLimit < 1 ||
+		chatLimit > 100)
+	return
+}
+
+func ChatFilterRule(pattern string, regex bool) (valid bool) {
+	length := len(pattern)
+	if length < 1 || length > 100 {
+		return
+	}
+
+	if regex {
+		_, err := regexp.Compile(pattern)
+		valid = err == nil
+		return
+	}
+
+	valid = true
+	return
+}
+
+func ChatLimitPeriod(chatLimitPeriod int) (valid bool) {
+	valid = !(chatLimitPeriod < 1 ||
+		chatLimitPeriod > 3600)
+	return
+}
+
 func Reason(reason string) (valid bool) {
 	valid = !(len(reason) > 500)
 	return
 }
 
+func DirectMessage(message string) (valid bool) {
+	length := utf8.RuneCountInString(message)
+	valid = !(length < 1 ||
+		length > 500)
+	return
+}
+
 func PlaylistName(playlistName string) (valid bool) {
 	length := len(playlistName)
 	valid = !(length < 2 ||
 		length > 30)
 	return
 }
+
+func PlaylistItemTitle(title string) (valid bool) {
+	length := len(title)
+	valid = !(length < 1 ||
+		length > 100)
+	return
+}
+
+func PlaylistItemArtist(artist string) (valid bool) {
+	length := len(artist)
+	valid = !(length < 1 ||
+		length > 100)
+	return
+}